@@ -2,6 +2,7 @@ package spellchecker
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/f1monkey/bitmap"
 )
@@ -42,3 +43,26 @@ func (a alphabet) encode(word []rune) bitmap.Bitmap32 {
 func (a alphabet) len() int {
 	return len(a)
 }
+
+// compare check if both alphabets consist of the same symbols
+func (a alphabet) compare(other alphabet) error {
+	var missing, extra []rune
+	for r := range a {
+		if _, ok := other[r]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	for r := range other {
+		if _, ok := a[r]; !ok {
+			extra = append(extra, r)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+
+	return fmt.Errorf("%w: missing symbols %q, extra symbols %q", ErrAlphabetMismatch, string(missing), string(extra))
+}
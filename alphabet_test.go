@@ -35,3 +35,22 @@ func Test_alphabet_encode(t *testing.T) {
 	result := ab.encode(word)
 	require.Equal(t, bitmap.Bitmap32{3}, result)
 }
+
+func Test_alphabet_compare(t *testing.T) {
+	ab, err := newAlphabet("abc")
+	require.NoError(t, err)
+
+	t.Run("must accept the same symbols in different order", func(t *testing.T) {
+		other, err := newAlphabet("cba")
+		require.NoError(t, err)
+		require.NoError(t, ab.compare(other))
+	})
+
+	t.Run("must report differing symbols", func(t *testing.T) {
+		other, err := newAlphabet("abd")
+		require.NoError(t, err)
+		err = ab.compare(other)
+		require.ErrorIs(t, err, ErrAlphabetMismatch)
+		require.Contains(t, err.Error(), `missing symbols "c", extra symbols "d"`)
+	})
+}
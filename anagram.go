@@ -0,0 +1,149 @@
+package spellchecker
+
+import (
+	"sort"
+
+	"github.com/f1monkey/bitmap"
+)
+
+// maxSubsetLetters limits the number of distinct letters for which SubAnagrams looks up every subset
+// of the letter bitmap in the index. Larger sets are checked word by word
+const maxSubsetLetters = 16
+
+// Anagrams returns dictionary words consisting of the same letters as the word, the word itself excluded.
+// Words are sorted by occurrence counters
+func (s *Spellchecker) Anagrams(word string) []string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	word = s.normalize(word)
+	runes := []rune(word)
+	letters := letterCounts(runes)
+
+	var hits []match
+	// words with the same letters share a bitmap, so they are in the same bucket
+	for _, id := range s.dict.index[sum(s.dict.alphabet.encode(runes))] {
+		candidate := s.dict.words[id]
+		if candidate == word || !isAnagram(letters, []rune(candidate)) {
+			continue
+		}
+		hits = append(hits, match{Value: candidate, Score: float64(s.dict.counts[id])})
+	}
+	sortMatches(hits)
+
+	return s.matchWords(hits)
+}
+
+// SubAnagrams returns dictionary words which can be built from the letters, every letter used at most once.
+// Words are sorted by length, longest first, then by occurrence counters
+func (s *Spellchecker) SubAnagrams(letters string) []string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	runes := []rune(s.normalize(letters))
+	available := letterCounts(runes)
+
+	var hits []match
+	collect := func(id uint32) {
+		candidate := []rune(s.dict.words[id])
+		if isSubAnagram(available, candidate) {
+			hits = append(hits, match{Value: s.dict.words[id], Score: float64(s.dict.counts[id])})
+		}
+	}
+
+	bm := s.dict.alphabet.encode(runes)
+	if bits := bitIndexes(bm); len(bits) <= maxSubsetLetters {
+		// a buildable word has a subset of the letters, so it is in the bucket of a subset bitmap.
+		// Bucket keys of different bitmaps may collide, so every bucket is read once
+		visited := make(map[uint64]struct{})
+		for mask := 1; mask < 1<<len(bits); mask++ {
+			var subset bitmap.Bitmap32
+			for i, bit := range bits {
+				if mask&(1<<i) != 0 {
+					subset.Set(bit)
+				}
+			}
+			key := sum(subset)
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+			for _, id := range s.dict.index[key] {
+				collect(id)
+			}
+		}
+	} else {
+		for id := range s.dict.words {
+			collect(id)
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		li, lj := len([]rune(hits[i].Value)), len([]rune(hits[j].Value))
+		if li != lj {
+			return li > lj
+		}
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Value < hits[j].Value
+	})
+
+	return s.matchWords(hits)
+}
+
+// matchWords returns matched words in their cased or original forms
+func (s *Spellchecker) matchWords(hits []match) []string {
+	result := make([]string, len(hits))
+	for i, h := range hits {
+		result[i] = s.wordCount(s.dict.id(h.Value)).Word
+	}
+
+	return result
+}
+
+// bitIndexes returns indexes of the set bits
+func bitIndexes(bm bitmap.Bitmap32) []uint32 {
+	var result []uint32
+	for i := range bm {
+		for j := uint32(0); j < 32; j++ {
+			if bm[i]&(1<<j) != 0 {
+				result = append(result, uint32(i)*32+j)
+			}
+		}
+	}
+
+	return result
+}
+
+func letterCounts(word []rune) map[rune]int {
+	result := make(map[rune]int, len(word))
+	for _, r := range word {
+		result[r]++
+	}
+
+	return result
+}
+
+// isAnagram check if the word consists of exactly the counted letters
+func isAnagram(letters map[rune]int, word []rune) bool {
+	total := 0
+	for _, cnt := range letters {
+		total += cnt
+	}
+
+	return len(word) == total && isSubAnagram(letters, word)
+}
+
+// isSubAnagram check if the word can be built from the counted letters
+func isSubAnagram(letters map[rune]int, word []rune) bool {
+	used := make(map[rune]int, len(word))
+	for _, r := range word {
+		used[r]++
+		if used[r] > letters[r] {
+			return false
+		}
+	}
+
+	return true
+}
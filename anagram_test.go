@@ -0,0 +1,52 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var anagramWords = []string{"listen", "silent", "silent", "enlist", "tinsel", "inlets", "list", "lens", "tin", "net", "ten", "tent", "listens"}
+
+func Test_Spellchecker_Anagrams(t *testing.T) {
+	t.Run("must return words with the same letters", func(t *testing.T) {
+		s := newTestSpellchecker(t, anagramWords...)
+		require.Equal(t, []string{"silent", "enlist", "inlets", "tinsel"}, s.Anagrams("listen"))
+		require.Equal(t, []string{"silent", "enlist", "inlets", "listen", "tinsel"}, s.Anagrams("Elints"))
+	})
+
+	t.Run("must check letter multiplicities", func(t *testing.T) {
+		s := newTestSpellchecker(t, anagramWords...)
+		// "tent" and "net" share the letter bitmap, but "t" is repeated
+		require.Equal(t, []string{"ten"}, s.Anagrams("net"))
+		require.Empty(t, s.Anagrams("tent"))
+	})
+}
+
+func Test_Spellchecker_SubAnagrams(t *testing.T) {
+	t.Run("must return words buildable from the letters", func(t *testing.T) {
+		s := newTestSpellchecker(t, anagramWords...)
+		require.Equal(t, []string{"silent", "enlist", "inlets", "listen", "tinsel", "lens", "list", "net", "ten", "tin"}, s.SubAnagrams("listen"))
+		require.Equal(t, []string{"tent", "net", "ten"}, s.SubAnagrams("tten"))
+		require.Empty(t, s.SubAnagrams("xyz"))
+	})
+
+	t.Run("must check every word for large letter sets", func(t *testing.T) {
+		s := newTestSpellchecker(t, anagramWords...)
+		require.Equal(t, []string{"tent", "net", "ten"}, s.SubAnagrams("ttenabcdfghjkmopqr"))
+	})
+
+	t.Run("must return every word once for alphabets longer than 32 letters", func(t *testing.T) {
+		s, err := New(cyrillicAlphabet)
+		require.NoError(t, err)
+		// "я" is the 33rd letter, its bitmap key collides with the key of "бг"
+		s.Add("бг", "гб", "юб", "ю")
+		require.Equal(t, []string{"бг", "гб", "юб", "ю"}, s.SubAnagrams("бгюя"))
+	})
+}
+
+func Test_bitIndexes(t *testing.T) {
+	alphabet, err := newAlphabet(DefaultAlphabet + "0123456789абвгд")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 2, 36, 40}, bitIndexes(alphabet.encode([]rune("cacaад"))))
+}
@@ -0,0 +1,122 @@
+package spellchecker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type casePattern byte
+
+const (
+	caseLower casePattern = iota
+	caseTitle
+	caseUpper
+	caseMixed
+)
+
+// casePatternOf detects the case pattern of the word. A single capital letter is a title case word
+func casePatternOf(word string) casePattern {
+	upper, lower := 0, 0
+	firstUpper := false
+	for i, r := range word {
+		switch {
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			upper++
+			if i == 0 {
+				firstUpper = true
+			}
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	switch {
+	case upper == 0:
+		return caseLower
+	case firstUpper && upper == 1:
+		return caseTitle
+	case lower == 0:
+		return caseUpper
+	}
+
+	return caseMixed
+}
+
+// applyCase converts the lowercase word to the case pattern of the original word.
+// Mixed case is not a valid case of a lowercase word, so the word is title-cased if the original starts
+// with a capital letter and left lowercase otherwise
+func applyCase(original, word string) string {
+	switch casePatternOf(original) {
+	case caseTitle:
+		return toTitle(word)
+	case caseUpper:
+		return strings.ToUpper(word)
+	case caseMixed:
+		if r, _ := utf8.DecodeRuneInString(original); unicode.IsUpper(r) || unicode.IsTitle(r) {
+			return toTitle(word)
+		}
+	}
+
+	return word
+}
+
+func toTitle(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToTitle(r)) + word[size:]
+}
+
+// AddCased adds case-sensitive words (proper nouns like "iPhone" or "McDonald") to dictionary.
+// Suggestions for such words keep their own case unless the input is written in capital letters,
+// and the words are correct only in their own case or in capital letters
+func (s *Spellchecker) AddCased(words ...string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.add(words...)
+	s.setForms(words...)
+	s.journal.write(opAdd, words...)
+	s.journal.write(opForm, words...)
+}
+
+// setForms marks the words as case-sensitive. The words must be present in the dictionary
+func (s *Spellchecker) setForms(words ...string) {
+	for _, word := range words {
+		if id := s.dict.id(s.normalize(word)); id > 0 {
+			s.dict.forms[id] = s.normalization.Form.String(word)
+		}
+	}
+}
+
+// isCorrect check if the normalized word is in the dictionary, is frequent enough and the original word has an acceptable case
+func (s *Spellchecker) isCorrect(word, normalized string) bool {
+	id := s.dict.id(normalized)
+	if id == 0 || s.dict.counts[id] < s.minCount {
+		return false
+	}
+
+	pattern := casePatternOf(word)
+	if form, ok := s.dict.forms[id]; ok {
+		word = s.normalization.Form.String(word)
+		return word == form || (pattern == caseUpper && word == strings.ToUpper(form))
+	}
+
+	return pattern != caseMixed
+}
+
+// withCase converts the dictionary word to its original form in the case of the input word.
+// Case-sensitive words keep their own case unless the input is written in capital letters
+func (s *Spellchecker) withCase(input, word string) string {
+	id := s.dict.id(word)
+	if form, ok := s.dict.forms[id]; ok {
+		if casePatternOf(input) == caseUpper && utf8.RuneCountInString(input) > 1 {
+			return strings.ToUpper(form)
+		}
+		return form
+	}
+	if original, ok := s.dict.originals[id]; ok {
+		word = original
+	}
+
+	return applyCase(input, word)
+}
@@ -0,0 +1,136 @@
+package spellchecker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_casePatternOf(t *testing.T) {
+	require.Equal(t, caseLower, casePatternOf("tea"))
+	require.Equal(t, caseTitle, casePatternOf("Tea"))
+	require.Equal(t, caseTitle, casePatternOf("I"))
+	require.Equal(t, caseUpper, casePatternOf("TEA"))
+	require.Equal(t, caseMixed, casePatternOf("tEa"))
+	require.Equal(t, caseMixed, casePatternOf("iPhone"))
+	require.Equal(t, caseLower, casePatternOf("123"))
+}
+
+func Test_applyCase(t *testing.T) {
+	require.Equal(t, "the", applyCase("teh", "the"))
+	require.Equal(t, "The", applyCase("Teh", "the"))
+	require.Equal(t, "THE", applyCase("TEH", "the"))
+	require.Equal(t, "the", applyCase("tEh", "the"))
+	require.Equal(t, "The", applyCase("TeH", "the"))
+	require.Equal(t, "Ёлка", applyCase("Елка", "ёлка"))
+}
+
+func Test_Spellchecker_Case(t *testing.T) {
+	t.Run("must fold case on lookup", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		require.True(t, s.IsCorrect("tea"))
+		require.True(t, s.IsCorrect("Tea"))
+		require.True(t, s.IsCorrect("TEA"))
+		require.False(t, s.IsCorrect("tEA"))
+	})
+
+	t.Run("must store added words case-folded", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		s.Add("Milk")
+		require.True(t, s.dict.has("milk"))
+		require.False(t, s.dict.has("Milk"))
+
+		s.Remove("MILK")
+		require.False(t, s.dict.has("milk"))
+	})
+
+	t.Run("must preserve input case in results", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		for input, expected := range map[string]string{"teh": "the", "Teh": "The", "TEH": "THE", "Tea": "Tea"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+
+		result, err := s.Suggest("TEH", 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"THE"}, result)
+	})
+
+	t.Run("must replace mixed case input with the dictionary form", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		require.False(t, s.IsCorrect("tHe"))
+		for input, expected := range map[string]string{"tHe": "the", "ThE": "The", "tEh": "the"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+
+		result, err := s.Suggest("tHe", 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"the"}, result)
+	})
+
+	t.Run("must keep case of case-sensitive words", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		require.True(t, s.IsCorrect("iPhone"))
+		require.True(t, s.IsCorrect("IPHONE"))
+		require.False(t, s.IsCorrect("iphone"))
+		require.False(t, s.IsCorrect("Iphone"))
+
+		for input, expected := range map[string]string{"iphone": "iPhone", "Iphnoe": "iPhone", "MCDONALD": "MCDONALD", "mcdonlad": "McDonald"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+	})
+
+	t.Run("must check case in texts", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		result := s.CheckText("The iphone TEA")
+		require.Equal(t, []Misspelling{{Token{Word: "iphone", Normalized: "iphone", Offset: 4}}}, result)
+		require.Equal(t, "The iPhone, THE tea", s.FixText("Teh iphone, TEH tea"))
+	})
+
+	t.Run("must persist case-sensitive words", func(t *testing.T) {
+		s := newTestSpellchecker(t, "the", "tea", "phone")
+		s.AddCased("iPhone", "McDonald")
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.Save(buf))
+
+		loaded, err := Load(buf)
+		require.NoError(t, err)
+		require.False(t, loaded.IsCorrect("iphone"))
+		require.True(t, loaded.IsCorrect("iPhone"))
+	})
+
+	t.Run("must journal case-sensitive words", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		s, err := New(DefaultAlphabet, WithJournal(buf))
+		require.NoError(t, err)
+		s.AddCased("iPhone")
+		require.Equal(t, "+ \"iPhone\"\n^ \"iPhone\"\n", buf.String())
+
+		replayed := newTestSpellchecker(t)
+		require.NoError(t, replayed.Replay(buf))
+		require.False(t, replayed.IsCorrect("iphone"))
+		require.True(t, replayed.IsCorrect("iPhone"))
+	})
+
+	t.Run("must merge case-sensitive words", func(t *testing.T) {
+		other := newTestSpellchecker(t, "the", "tea", "phone")
+		other.AddCased("iPhone", "McDonald")
+
+		s := newTestSpellchecker(t)
+		require.NoError(t, s.Merge(other, MergeSum))
+		require.False(t, s.IsCorrect("iphone"))
+		require.True(t, s.IsCorrect("iPhone"))
+	})
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/f1monkey/spellchecker/eval"
+	"github.com/f1monkey/spellchecker/ispell"
+	"github.com/f1monkey/spellchecker/lsp"
+)
+
+func newFlagSet(e env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+
+	return fs
+}
+
+func runBuild(e env, args []string) error {
+	fs := newFlagSet(e, "build")
+	alphabet := fs.String("alphabet", spellchecker.DefaultAlphabet, "allowed symbols")
+	maxErrors := fs.Int("max-errors", spellchecker.DefaultMaxErrors, "max errors")
+	minCount := fs.Int("min-count", 0, "remove words occurred less than min-count times")
+	maxWords := fs.Int("max-words", 0, "keep only max-words most frequent words, 0 means no limit")
+	out := fs.String("o", "", "output dictionary path (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("output path is required")
+	}
+
+	s, err := spellchecker.New(
+		*alphabet,
+		spellchecker.WithMaxErrors(*maxErrors),
+		spellchecker.WithMaxVocabulary(*maxWords),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = eachInput(e, fs.Args(), func(name string, r io.Reader) error {
+		return s.AddFrom(r)
+	})
+	if err != nil {
+		return err
+	}
+	s.Prune(*minCount)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := s.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func runCheck(e env, args []string) error {
+	fs := newFlagSet(e, "check")
+	dict := fs.String("d", "", "dictionary path (required)")
+	n := fs.Int("n", 3, "number of suggestions to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := loadDictionary(*dict)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	err = eachInput(e, fs.Args(), func(name string, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		text := string(data)
+		for _, m := range s.CheckTextWith(text, spellchecker.MarkupTokenizer(name, nil)) {
+			found = true
+			line, col := position(text, m.Offset)
+			suggestions, _ := s.Suggest(m.Word, *n)
+			fmt.Fprintf(e.stdout, "%s:%d:%d: %s", name, line, col, m.Word)
+			if len(suggestions) > 0 && suggestions[0] != m.Word {
+				fmt.Fprintf(e.stdout, " (%s)", strings.Join(suggestions, ", "))
+			}
+			fmt.Fprintln(e.stdout)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if found {
+		return errMisspelled
+	}
+
+	return nil
+}
+
+func runFix(e env, args []string) error {
+	fs := newFlagSet(e, "fix")
+	dict := fs.String("d", "", "dictionary path (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := loadDictionary(*dict)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		data, err := io.ReadAll(e.stdin)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(e.stdout, s.FixText(string(data)))
+		return err
+	}
+
+	for _, name := range fs.Args() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+
+		fixed := s.FixTextWith(string(data), spellchecker.MarkupTokenizer(name, nil))
+		if fixed == string(data) {
+			continue
+		}
+		if err := os.WriteFile(name, []byte(fixed), info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runSuggest(e env, args []string) error {
+	fs := newFlagSet(e, "suggest")
+	dict := fs.String("d", "", "dictionary path (required)")
+	n := fs.Int("n", 10, "number of suggestions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := loadDictionary(*dict)
+	if err != nil {
+		return err
+	}
+
+	suggest := func(word string) {
+		suggestions, err := s.Suggest(word, *n)
+		if errors.Is(err, spellchecker.ErrUnknownWord) {
+			fmt.Fprintf(e.stdout, "%s: -\n", word)
+			return
+		}
+		fmt.Fprintf(e.stdout, "%s: %s\n", word, strings.Join(suggestions, " "))
+	}
+
+	if fs.NArg() > 0 {
+		for _, word := range fs.Args() {
+			suggest(word)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(e.stdin)
+	fmt.Fprint(e.stderr, "> ")
+	for scanner.Scan() {
+		for _, word := range strings.Fields(scanner.Text()) {
+			suggest(word)
+		}
+		fmt.Fprint(e.stderr, "> ")
+	}
+
+	return scanner.Err()
+}
+
+func runStats(e env, args []string) error {
+	fs := newFlagSet(e, "stats")
+	dict := fs.String("d", "", "dictionary path (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := loadDictionary(*dict)
+	if err != nil {
+		return err
+	}
+
+	stats := s.Stats()
+	fmt.Fprintf(e.stdout, "words:         %d\n", stats.Words)
+	fmt.Fprintf(e.stdout, "total count:   %d\n", stats.TotalCount)
+	fmt.Fprintf(e.stdout, "alphabet size: %d\n", stats.AlphabetSize)
+	fmt.Fprintf(e.stdout, "alphabet used: %d\n", stats.AlphabetUsed)
+	fmt.Fprintf(e.stdout, "buckets:       %d\n", stats.Buckets)
+	fmt.Fprintf(e.stdout, "max bucket:    %d\n", stats.MaxBucketSize)
+	fmt.Fprintf(e.stdout, "memory:        %d bytes\n", stats.MemoryBytes)
+
+	return nil
+}
+
+func runEval(e env, args []string) error {
+	fs := newFlagSet(e, "eval")
+	dict := fs.String("d", "", "dictionary path (required)")
+	k := fs.Int("k", eval.DefaultTopK, "number of suggestions to request")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	failures := fs.Bool("failures", false, "print failures (text and csv formats)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := loadDictionary(*dict)
+	if err != nil {
+		return err
+	}
+
+	var corrections []spellchecker.Correction
+	err = eachInput(e, fs.Args(), func(name string, r io.Reader) error {
+		items, err := spellchecker.ReadCorrections(r)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		corrections = append(corrections, items...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	report := eval.Run(s, corrections, *k)
+	switch *format {
+	case "json":
+		return report.WriteJSON(e.stdout)
+	case "csv":
+		if err := report.WriteCSV(e.stdout); err != nil {
+			return err
+		}
+		if *failures {
+			return report.WriteFailuresCSV(e.stdout)
+		}
+		return nil
+	case "text":
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	fmt.Fprintf(e.stdout, "total:        %d\n", report.Total)
+	fmt.Fprintf(e.stdout, "top-1:        %.2f%%\n", report.Top1*100)
+	fmt.Fprintf(e.stdout, "%-14s%.2f%%\n", fmt.Sprintf("top-%d:", report.K), report.TopK*100)
+	fmt.Fprintf(e.stdout, "mrr:          %.4f\n", report.MRR)
+	fmt.Fprintf(e.stdout, "unknown rate: %.2f%%\n", report.UnknownRate*100)
+	fmt.Fprintf(e.stdout, "latency:      p50=%s p90=%s p99=%s max=%s\n",
+		report.Latency.P50, report.Latency.P90, report.Latency.P99, report.Latency.Max)
+	if *failures {
+		for _, f := range report.Failures {
+			fmt.Fprintf(e.stdout, "%s: expected %s, got %s\n", f.Word, f.Expected, strings.Join(f.Suggestions, " "))
+		}
+	}
+
+	return nil
+}
+
+func runPipe(e env, args []string) error {
+	fs := newFlagSet(e, "pipe")
+	dict := fs.String("d", "", "dictionary path (required), personal words are appended to its journal")
+	n := fs.Int("n", ispell.DefaultMaxSuggestions, "max number of suggestions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dict == "" {
+		return errors.New("dictionary path is required")
+	}
+
+	s, err := spellchecker.Open(*dict)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	server := &ispell.Server{
+		Checker:        s,
+		MaxSuggestions: *n,
+		Save:           s.Compact,
+	}
+
+	return server.Serve(e.stdin, e.stdout)
+}
+
+func runLSP(e env, args []string) error {
+	fs := newFlagSet(e, "lsp")
+	dict := fs.String("d", "", "dictionary path (required), added words are appended to its journal")
+	n := fs.Int("n", lsp.DefaultMaxSuggestions, "max number of suggestions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dict == "" {
+		return errors.New("dictionary path is required")
+	}
+
+	s, err := spellchecker.Open(*dict)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	server := &lsp.Server{
+		Checker:        s,
+		MaxSuggestions: *n,
+		Save:           s.Compact,
+	}
+
+	return server.Serve(e.stdin, e.stdout)
+}
+
+// eachInput calls fn for every file or for stdin if there are no files
+func eachInput(e env, files []string, fn func(name string, r io.Reader) error) error {
+	if len(files) == 0 {
+		return fn("<stdin>", e.stdin)
+	}
+
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = fn(name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// position converts a byte offset to 1-based line and column numbers
+func position(text string, offset int) (int, int) {
+	line := 1 + strings.Count(text[:offset], "\n")
+	col := offset - strings.LastIndex(text[:offset], "\n")
+
+	return line, col
+}
@@ -0,0 +1,91 @@
+// Command spellchecker builds and queries spellchecker dictionaries
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/f1monkey/spellchecker"
+)
+
+const usage = `usage: spellchecker <command> [flags] [args]
+
+commands:
+  build    build a dictionary from corpus files and save it
+  check    report misspelled words in files
+  fix      rewrite files replacing misspelled words
+  suggest  print suggestions for words (reads stdin if no words provided)
+  stats    print dictionary statistics
+  eval     evaluate dictionary accuracy on "right: wrong1 wrong2" files
+  pipe     serve "ispell -a" pipe protocol over stdin/stdout ("-a" is an alias)
+  serve    serve HTTP JSON API (SIGHUP reloads the dictionary)
+  lsp      run Language Server Protocol server over stdin/stdout
+
+run "spellchecker <command> -h" for command flags
+`
+
+// errMisspelled is returned by check command if any misspelled words were found
+var errMisspelled = errors.New("misspelled words found")
+
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command func(e env, args []string) error
+
+var commands = map[string]command{
+	"build":   runBuild,
+	"check":   runCheck,
+	"fix":     runFix,
+	"suggest": runSuggest,
+	"stats":   runStats,
+	"eval":    runEval,
+	"pipe":    runPipe,
+	"-a":      runPipe,
+	"serve":   runServe,
+	"lsp":     runLSP,
+}
+
+func main() {
+	err := run(os.Args[1:], env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr})
+	if errors.Is(err, errMisspelled) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+func run(args []string, e env) error {
+	if len(args) == 0 {
+		fmt.Fprint(e.stderr, usage)
+		return errors.New("command is required")
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(e.stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return cmd(e, args[1:])
+}
+
+// loadDictionary loads a saved dictionary and replays its journal if there is one
+func loadDictionary(path string) (*spellchecker.Spellchecker, error) {
+	if path == "" {
+		return nil, errors.New("dictionary path is required")
+	}
+
+	s, err := spellchecker.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", path, err)
+	}
+
+	return s, nil
+}
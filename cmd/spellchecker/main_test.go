@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runCmd(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	stdout := &bytes.Buffer{}
+	err := run(args, env{
+		stdin:  strings.NewReader(stdin),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+	})
+
+	return stdout.String(), err
+}
+
+func buildDictionary(t *testing.T) string {
+	t.Helper()
+
+	dict := filepath.Join(t.TempDir(), "dict.bin")
+	_, err := runCmd(t, "", "build", "-o", dict, "../../data/sample.txt")
+	require.NoError(t, err)
+
+	return dict
+}
+
+func Test_run(t *testing.T) {
+	t.Run("must fail without command", func(t *testing.T) {
+		_, err := runCmd(t, "")
+		require.Error(t, err)
+	})
+
+	t.Run("must fail on unknown command", func(t *testing.T) {
+		_, err := runCmd(t, "", "unknown")
+		require.Error(t, err)
+	})
+}
+
+func Test_runBuild(t *testing.T) {
+	t.Run("must read corpus from stdin", func(t *testing.T) {
+		dict := filepath.Join(t.TempDir(), "dict.bin")
+		_, err := runCmd(t, "green tea", "build", "-o", dict)
+		require.NoError(t, err)
+
+		out, err := runCmd(t, "", "stats", "-d", dict)
+		require.NoError(t, err)
+		require.Contains(t, out, "words:         2\n")
+	})
+
+	t.Run("must prune rare words", func(t *testing.T) {
+		dict := filepath.Join(t.TempDir(), "dict.bin")
+		_, err := runCmd(t, "green tea tea black tea green", "build", "-min-count", "2", "-max-words", "1", "-o", dict)
+		require.NoError(t, err)
+
+		out, err := runCmd(t, "", "stats", "-d", dict)
+		require.NoError(t, err)
+		require.Contains(t, out, "words:         1\n")
+		require.Contains(t, out, "total count:   3\n")
+	})
+
+	t.Run("must use max errors", func(t *testing.T) {
+		dict := filepath.Join(t.TempDir(), "dict.bin")
+		_, err := runCmd(t, "problem", "build", "-max-errors", "1", "-o", dict)
+		require.NoError(t, err)
+
+		out, err := runCmd(t, "", "suggest", "-d", dict, "problam", "porblem")
+		require.NoError(t, err)
+		require.Equal(t, "problam: problem\nporblem: -\n", out)
+	})
+
+	t.Run("must require output path", func(t *testing.T) {
+		_, err := runCmd(t, "green tea", "build")
+		require.Error(t, err)
+	})
+}
+
+func Test_runCheck(t *testing.T) {
+	dict := buildDictionary(t)
+
+	file := filepath.Join(t.TempDir(), "text.txt")
+	require.NoError(t, os.WriteFile(file, []byte("green tea\nan oragne"), 0o644))
+
+	out, err := runCmd(t, "", "check", "-d", dict, file)
+	require.ErrorIs(t, err, errMisspelled)
+	require.Equal(t, file+":2:1: an\n"+file+":2:4: oragne (orange)\n", out)
+
+	_, err = runCmd(t, "green tea", "check", "-d", dict)
+	require.NoError(t, err)
+}
+
+func Test_runFix(t *testing.T) {
+	dict := buildDictionary(t)
+
+	t.Run("must rewrite files", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "text.txt")
+		require.NoError(t, os.WriteFile(file, []byte("green tea and oragne"), 0o644))
+
+		_, err := runCmd(t, "", "fix", "-d", dict, file)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, "green tea and orange", string(data))
+	})
+
+	t.Run("must fix stdin", func(t *testing.T) {
+		out, err := runCmd(t, "problam", "fix", "-d", dict)
+		require.NoError(t, err)
+		require.Equal(t, "problem", out)
+	})
+}
+
+func Test_runSuggest(t *testing.T) {
+	dict := buildDictionary(t)
+
+	out, err := runCmd(t, "", "suggest", "-d", dict, "arang", "xyzxyz")
+	require.NoError(t, err)
+	require.Equal(t, "arang: orange range\nxyzxyz: -\n", out)
+
+	out, err = runCmd(t, "arang\nproblam\n", "suggest", "-d", dict, "-n", "1")
+	require.NoError(t, err)
+	require.Equal(t, "arang: orange\nproblam: problem\n", out)
+}
+
+func Test_runEval(t *testing.T) {
+	dict := buildDictionary(t)
+
+	out, err := runCmd(t, "problem: problam\nrange: arang\n", "eval", "-d", dict, "-format", "csv")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[1], "2,10,0.5000,1.0000,0.7500,"))
+
+	out, err = runCmd(t, "problem: problam\n", "eval", "-d", dict)
+	require.NoError(t, err)
+	require.Contains(t, out, "top-1:        100.00%")
+}
+
+func Test_loadDictionary(t *testing.T) {
+	dict := buildDictionary(t)
+	require.NoError(t, os.WriteFile(dict+".journal", []byte("+ \"kubernetes\"\n"), 0o644))
+
+	s, err := loadDictionary(dict)
+	require.NoError(t, err)
+	require.True(t, s.IsCorrect("kubernetes"))
+}
+
+func Test_runPipe(t *testing.T) {
+	dict := buildDictionary(t)
+
+	out, err := runCmd(t, "!\n*kubernetes\n#\nkubernetes arang\n", "-a", "-d", dict)
+	require.NoError(t, err)
+	require.Contains(t, out, "& arang 2 11: orange, range\n")
+
+	s, err := loadDictionary(dict)
+	require.NoError(t, err)
+	require.True(t, s.IsCorrect("kubernetes"))
+}
+
+func Test_runLSP(t *testing.T) {
+	dict := buildDictionary(t)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+	input := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+
+	out, err := runCmd(t, input, "lsp", "-d", dict)
+	require.NoError(t, err)
+	require.Contains(t, out, `"codeActionProvider":true`)
+}
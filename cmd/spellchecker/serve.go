@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/f1monkey/spellchecker/server"
+)
+
+func runServe(e env, args []string) error {
+	fs := newFlagSet(e, "serve")
+	dict := fs.String("d", "", "dictionary path (required)")
+	addr := fs.String("addr", ":8080", "listen address")
+	maxBodySize := fs.Int64("max-body-size", server.DefaultMaxBodySize, "max request body size in bytes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *dict == "" {
+		return errors.New("dictionary path is required")
+	}
+
+	// words added with the API are appended to the journal of the dictionary, so they survive reloads and restarts
+	checker, err := spellchecker.Open(*dict)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", *dict, err)
+	}
+	defer checker.Close()
+
+	handler, err := server.New(checker, server.WithDictionaryFile(*dict), server.WithMaxBodySize(*maxBodySize))
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	for {
+		select {
+		case err := <-errs:
+			return err
+		case sig := <-signals:
+			if sig == syscall.SIGHUP {
+				if err := handler.Reload(); err != nil {
+					fmt.Fprintf(e.stderr, "reload: %s\n", err)
+				}
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err := srv.Shutdown(ctx)
+			cancel()
+			if err != nil {
+				return err
+			}
+			if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package spellchecker
+
+import (
+	"sort"
+	"strings"
+)
+
+// sortedIDs returns IDs of words in lexicographical order, rebuilding them after dictionary changes
+func (d *dictionary) sortedIDs() []uint32 {
+	d.sortedMtx.Lock()
+	defer d.sortedMtx.Unlock()
+
+	if d.sorted == nil {
+		sorted := make([]uint32, 0, len(d.words))
+		for id := range d.words {
+			sorted = append(sorted, id)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return d.words[sorted[i]] < d.words[sorted[j]] })
+		d.sorted = sorted
+	}
+
+	return d.sorted
+}
+
+// withPrefix calls fn for every word starting with the prefix in lexicographical order. Stops when fn returns false
+func (d *dictionary) withPrefix(prefix string, fn func(id uint32, word string) bool) {
+	sorted := d.sortedIDs()
+	start := sort.Search(len(sorted), func(i int) bool { return d.words[sorted[i]] >= prefix })
+	for _, id := range sorted[start:] {
+		word := d.words[id]
+		if !strings.HasPrefix(word, prefix) {
+			return
+		}
+		if !fn(id, word) {
+			return
+		}
+	}
+}
+
+// Complete returns up to n most frequent dictionary words starting with the prefix.
+// Words keep the case of the prefix
+func (s *Spellchecker) Complete(prefix string, n int) []string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.complete(prefix, n)
+}
+
+func (s *Spellchecker) complete(prefix string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	var hits []match
+	s.dict.withPrefix(s.normalize(prefix), func(id uint32, word string) bool {
+		hits = append(hits, match{Value: word, Score: float64(s.dict.counts[id])})
+		return true
+	})
+	sortMatches(hits)
+	if len(hits) > n {
+		hits = hits[:n]
+	}
+
+	result := make([]string, len(hits))
+	for i, h := range hits {
+		result[i] = s.withCase(prefix, h.Value)
+	}
+
+	return result
+}
+
+// sortMatches sorts matches by score descending, then by value
+func sortMatches(matches []match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score == matches[j].Score {
+			return matches[i].Value < matches[j].Value
+		}
+		return matches[i].Score > matches[j].Score
+	})
+}
+
+// CompleteFuzzy returns up to n dictionary words whose prefix is within maxErrors edits of the typed prefix:
+// "recie" => "receive", "recipe". Words are ranked by the score function of the spellchecker
+// applied to the prefix and the closest prefix of the word. Words keep the case of the prefix
+func (s *Spellchecker) CompleteFuzzy(prefix string, n, maxErrors int) []string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if n <= 0 {
+		return nil
+	}
+
+	typed := []rune(s.normalize(prefix))
+	if len(typed) == 0 {
+		return s.complete(prefix, n)
+	}
+
+	hits := s.dict.fuzzyPrefix(typed, maxErrors)
+	sortMatches(hits)
+	if len(hits) > n {
+		hits = hits[:n]
+	}
+
+	result := make([]string, len(hits))
+	for i, h := range hits {
+		result[i] = s.withCase(prefix, h.Value)
+	}
+
+	return result
+}
+
+// fuzzyPrefix scores words having a prefix within maxErrors edits of the typed one.
+// Sorted words are walked like a trie: Levenshtein rows of a common prefix are shared between
+// neighbours, and words under a prefix which can't get closer are not walked any further
+func (d *dictionary) fuzzyPrefix(typed []rune, maxErrors int) []match {
+	sorted := d.sortedIDs()
+
+	first := make([]int, len(typed)+1)
+	for i := range first {
+		first[i] = i
+	}
+	// rows[j] are distances between prefixes of the typed string and word[:j]
+	rows := [][]int{first}
+	// best[j] is the closest prefix of word[:j] as {distance, length}
+	best := [][2]int{{len(typed) + 1, 0}}
+
+	var hits []match
+	var prev []rune
+	for i := 0; i < len(sorted); {
+		word := []rune(d.words[sorted[i]])
+		common := commonPrefixLen(prev, word)
+		rows, best = rows[:common+1], best[:common+1]
+
+		pruned := 0
+		for j := common; j < len(word); j++ {
+			row := levenshteinRow(rows[j], typed, word[j])
+			rows = append(rows, row)
+
+			closest := best[j]
+			if row[len(typed)] < closest[0] {
+				closest = [2]int{row[len(typed)], j + 1}
+			}
+			best = append(best, closest)
+
+			if minInt(row) > maxErrors {
+				pruned = j + 1
+				break
+			}
+		}
+
+		// words sharing a pruned prefix have the same closest prefix, the current word ends the block otherwise
+		end := i + 1
+		if pruned > 0 {
+			cut := string(word[:pruned])
+			end = i + sort.Search(len(sorted)-i, func(k int) bool {
+				w := d.words[sorted[i+k]]
+				return w > cut && !strings.HasPrefix(w, cut)
+			})
+			word = word[:pruned]
+		}
+
+		if closest := best[len(rows)-1]; closest[0] <= maxErrors {
+			for _, id := range sorted[i:end] {
+				hits = append(hits, match{
+					Value: d.words[id],
+					Score: d.scoreFunc(typed, word[:closest[1]], closest[0], d.counts[id]),
+				})
+			}
+		}
+		prev = word
+		i = end
+	}
+
+	return hits
+}
+
+// levenshteinRow computes the next row of the Levenshtein matrix after appending r to the word
+func levenshteinRow(prev []int, typed []rune, r rune) []int {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if typed[i-1] == r {
+			cost = 0
+		}
+		row[i] = prev[i-1] + cost
+		if v := prev[i] + 1; v < row[i] {
+			row[i] = v
+		}
+		if v := row[i-1] + 1; v < row[i] {
+			row[i] = v
+		}
+	}
+
+	return row
+}
+
+func commonPrefixLen(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
+
+func minInt(values []int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+
+	return result
+}
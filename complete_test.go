@@ -0,0 +1,140 @@
+package spellchecker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var completeWords = []string{"tea", "tea", "tea", "teapot", "team", "team", "teach", "ten", "green"}
+
+func Test_Spellchecker_Complete(t *testing.T) {
+	t.Run("must return the most frequent words with the prefix", func(t *testing.T) {
+		s := newTestSpellchecker(t, completeWords...)
+		require.Equal(t, []string{"tea", "team", "teach"}, s.Complete("tea", 3))
+		require.Equal(t, []string{"tea", "team", "teach", "teapot", "ten"}, s.Complete("te", 10))
+		require.Equal(t, []string{"green"}, s.Complete("g", 10))
+		require.Empty(t, s.Complete("x", 10))
+	})
+
+	t.Run("must keep the case of the prefix", func(t *testing.T) {
+		s := newTestSpellchecker(t, completeWords...)
+		require.Equal(t, []string{"Tea", "Team"}, s.Complete("Te", 2))
+	})
+
+	t.Run("must return nothing for non-positive n", func(t *testing.T) {
+		s := newTestSpellchecker(t, completeWords...)
+		require.Nil(t, s.Complete("te", 0))
+		require.Nil(t, s.Complete("te", -1))
+	})
+
+	t.Run("must see dictionary changes", func(t *testing.T) {
+		s := newTestSpellchecker(t, completeWords...)
+		require.Equal(t, []string{"green"}, s.Complete("gr", 10))
+
+		s.Add("grey")
+		s.Remove("green")
+		require.Equal(t, []string{"grey"}, s.Complete("gr", 10))
+	})
+
+	t.Run("must persist the sorted index", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, newTestSpellchecker(t, completeWords...).Save(buf))
+
+		s, err := Load(buf)
+		require.NoError(t, err)
+		require.Len(t, s.dict.sorted, 6)
+		require.Equal(t, []string{"tea", "team"}, s.Complete("tea", 2))
+	})
+}
+
+func Test_dictionary_withPrefix(t *testing.T) {
+	dict, err := newDictionary(DefaultAlphabet, defaultScorefunc, DefaultMaxErrors)
+	require.NoError(t, err)
+	for _, word := range []string{"b", "ab", "abc", "a", "abd", "ac"} {
+		dict.add(word)
+	}
+
+	var result []string
+	dict.withPrefix("ab", func(_ uint32, word string) bool {
+		result = append(result, word)
+		return len(result) < 2
+	})
+	require.Equal(t, []string{"ab", "abc"}, result)
+}
+
+func Test_Spellchecker_CompleteFuzzy(t *testing.T) {
+	s := newTestSpellchecker(t, "receive", "receive", "receive", "recipe", "recipe", "recent", "reception", "deceive", "relieve")
+
+	t.Run("must tolerate typos in the prefix", func(t *testing.T) {
+		require.Equal(t, []string{"receive", "recipe"}, s.CompleteFuzzy("recie", 2, 1))
+		require.ElementsMatch(t, []string{"receive", "recipe", "recent", "reception", "relieve"}, s.CompleteFuzzy("recie", 10, 1))
+	})
+
+	t.Run("must find more words with more errors", func(t *testing.T) {
+		require.Empty(t, s.CompleteFuzzy("dfcie", 10, 1))
+		require.Contains(t, s.CompleteFuzzy("dfcie", 10, 2), "deceive")
+	})
+
+	t.Run("must rank exact prefixes first", func(t *testing.T) {
+		require.Equal(t, []string{"deceive"}, s.CompleteFuzzy("dece", 1, 2))
+		require.Equal(t, []string{"recent", "reception"}, s.CompleteFuzzy("rece", 10, 0)[1:])
+	})
+
+	t.Run("must keep the case of the prefix", func(t *testing.T) {
+		require.Equal(t, []string{"Receive"}, s.CompleteFuzzy("Recie", 1, 1))
+	})
+
+	t.Run("must return nothing for non-positive n", func(t *testing.T) {
+		require.Nil(t, s.CompleteFuzzy("recie", 0, 1))
+		require.Nil(t, s.CompleteFuzzy("recie", -1, 1))
+		require.Nil(t, s.CompleteFuzzy("", -1, 1))
+	})
+
+	t.Run("must fall back to Complete for an empty prefix", func(t *testing.T) {
+		require.Equal(t, []string{"receive"}, s.CompleteFuzzy("", 1, 1))
+	})
+}
+
+func Test_dictionary_fuzzyPrefix(t *testing.T) {
+	dict, err := newDictionary(DefaultAlphabet, defaultScorefunc, DefaultMaxErrors)
+	require.NoError(t, err)
+	words := []string{"a", "ab", "abc", "abd", "b", "ba", "bca", "xyz", "xyzab"}
+	for _, word := range words {
+		dict.add(word)
+	}
+
+	for _, typed := range []string{"ab", "ba", "abx", "xz", "q"} {
+		for maxErrors := 0; maxErrors <= 2; maxErrors++ {
+			// brute force: the closest prefix of every word
+			var expected []string
+			for _, word := range words {
+				runes := []rune(word)
+				closest := len(typed) + 1
+				for j := 1; j <= len(runes); j++ {
+					prefix := []rune(typed)
+					row := make([]int, len(prefix)+1)
+					for i := range row {
+						row[i] = i
+					}
+					for _, r := range runes[:j] {
+						row = levenshteinRow(row, prefix, r)
+					}
+					if row[len(prefix)] < closest {
+						closest = row[len(prefix)]
+					}
+				}
+				if closest <= maxErrors {
+					expected = append(expected, word)
+				}
+			}
+
+			var result []string
+			for _, h := range dict.fuzzyPrefix([]rune(typed), maxErrors) {
+				result = append(result, h.Value)
+			}
+			require.ElementsMatch(t, expected, result, "%s with %d errors", typed, maxErrors)
+		}
+	}
+}
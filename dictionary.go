@@ -5,6 +5,7 @@ import (
 	"encoding"
 	"encoding/gob"
 	"sort"
+	"sync"
 	"sync/atomic"
 
 	"github.com/agnivade/levenshtein"
@@ -21,9 +22,17 @@ type dictionary struct {
 	words  map[uint32]string
 	ids    map[string]uint32
 	counts map[uint32]int
+	// forms case-sensitive forms of words
+	forms map[uint32]string
+	// originals original forms of words which differ from normalized ones
+	originals map[uint32]string
 
 	index map[uint64][]uint32
 
+	// sorted IDs of words in lexicographical order for prefix search, built lazily
+	sortedMtx sync.Mutex
+	sorted    []uint32
+
 	scoreFunc scoreFunc
 }
 
@@ -40,6 +49,8 @@ func newDictionary(ab string, scoreFunc scoreFunc, maxErrors int) (*dictionary,
 		ids:       make(map[string]uint32),
 		words:     make(map[uint32]string),
 		counts:    make(map[uint32]int),
+		forms:     make(map[uint32]string),
+		originals: make(map[uint32]string),
 		index:     make(map[uint64][]uint32),
 		scoreFunc: scoreFunc,
 	}, nil
@@ -50,6 +61,15 @@ func (d *dictionary) id(word string) uint32 {
 	return d.ids[word]
 }
 
+// original get the original form of the word by ID
+func (d *dictionary) original(id uint32) string {
+	if original, ok := d.originals[id]; ok {
+		return original
+	}
+
+	return d.words[id]
+}
+
 // has check if the word is present in the dictionary
 func (d *dictionary) has(word string) bool {
 	return d.ids[word] > 0
@@ -65,10 +85,57 @@ func (d *dictionary) add(word string) (uint32, error) {
 	d.words[id] = word
 	key := sum(d.alphabet.encode(runes))
 	d.index[key] = append(d.index[key], id)
+	d.sorted = nil
 
 	return id, nil
 }
 
+// remove deletes the word from the dictionary
+func (d *dictionary) remove(id uint32) {
+	word, ok := d.words[id]
+	if !ok {
+		return
+	}
+
+	delete(d.ids, word)
+	delete(d.words, id)
+	delete(d.counts, id)
+	delete(d.forms, id)
+	delete(d.originals, id)
+	d.sorted = nil
+
+	key := sum(d.alphabet.encode([]rune(word)))
+	ids := d.index[key]
+	for i := range ids {
+		if ids[i] == id {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	if len(ids) == 0 {
+		delete(d.index, key)
+		return
+	}
+	d.index[key] = ids
+}
+
+// set changes word occurence counter, adding the word if needed.
+// The word is removed if the counter is less than 1
+func (d *dictionary) set(word string, count int) {
+	id := d.id(word)
+	if count < 1 {
+		if id > 0 {
+			d.remove(id)
+		}
+		return
+	}
+
+	if id == 0 {
+		id, _ = d.add(word)
+	}
+	d.counts[id] = count
+}
+
 // inc increase word occurence counter
 func (d *dictionary) inc(id uint32) {
 	_, ok := d.counts[id]
@@ -188,12 +255,15 @@ var _ encoding.BinaryMarshaler = (*dictionary)(nil)
 var _ encoding.BinaryUnmarshaler = (*dictionary)(nil)
 
 type dictData struct {
-	Alphabet alphabet
-	IDs      map[string]uint32
-	Words    map[uint32]string
-	Counts   map[uint32]int
+	Alphabet  alphabet
+	IDs       map[string]uint32
+	Words     map[uint32]string
+	Counts    map[uint32]int
+	Forms     map[uint32]string
+	Originals map[uint32]string
 
-	Index map[uint64][]uint32
+	Index  map[uint64][]uint32
+	Sorted []uint32
 
 	MaxErrors int
 }
@@ -204,7 +274,10 @@ func (d *dictionary) MarshalBinary() ([]byte, error) {
 		IDs:       d.ids,
 		Words:     d.words,
 		Counts:    d.counts,
+		Forms:     d.forms,
+		Originals: d.originals,
 		Index:     d.index,
+		Sorted:    d.sortedIDs(),
 		MaxErrors: d.maxErrors,
 	}
 
@@ -228,7 +301,19 @@ func (d *dictionary) UnmarshalBinary(data []byte) error {
 	d.ids = dictData.IDs
 	d.counts = dictData.Counts
 	d.words = dictData.Words
+	d.forms = dictData.Forms
+	if d.forms == nil {
+		d.forms = make(map[uint32]string)
+	}
+	d.originals = dictData.Originals
+	if d.originals == nil {
+		d.originals = make(map[uint32]string)
+	}
 	d.index = dictData.Index
+	d.sorted = dictData.Sorted
+	if len(d.sorted) != len(d.words) {
+		d.sorted = nil
+	}
 	d.maxErrors = dictData.MaxErrors
 	d.scoreFunc = defaultScorefunc
 
@@ -60,3 +60,25 @@ func Test_Dictionary_Inc(t *testing.T) {
 		require.Equal(t, 0, dict.counts[2])
 	})
 }
+
+func Test_dictionary_remove(t *testing.T) {
+	t.Run("must remove word from dictionary index", func(t *testing.T) {
+		dict, err := newDictionary(DefaultAlphabet, defaultScorefunc, DefaultMaxErrors)
+		require.NoError(t, err)
+
+		id1, err := dict.add("qwe")
+		require.NoError(t, err)
+		id2, err := dict.add("ewq")
+		require.NoError(t, err)
+
+		dict.remove(id1)
+		require.False(t, dict.has("qwe"))
+		require.NotContains(t, dict.words, id1)
+		require.NotContains(t, dict.counts, id1)
+		require.Len(t, dict.index, 1)
+
+		dict.remove(id2)
+		require.Len(t, dict.ids, 0)
+		require.Len(t, dict.index, 0)
+	})
+}
@@ -0,0 +1,303 @@
+package spellchecker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Correction is a correct word with its known misspellings
+type Correction struct {
+	Right string
+	Wrong []string
+}
+
+// ReadCorrections reads corrections in "right: wrong1 wrong2" format, one correct word per line
+func ReadCorrections(r io.Reader) ([]Correction, error) {
+	var result []Correction
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		right, wrong, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing \":\" separator", line)
+		}
+		right = strings.TrimSpace(right)
+		if right == "" {
+			return nil, fmt.Errorf("line %d: empty correct word", line)
+		}
+
+		result = append(result, Correction{
+			Right: right,
+			Wrong: strings.Fields(wrong),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type editKind byte
+
+const (
+	editInsert editKind = iota + 1
+	editDelete
+	editSubstitute
+	editTranspose
+)
+
+// edit is a single character edit turning a correct word into a typo
+type edit struct {
+	kind editKind
+	// a is a correct symbol (the first one for transpositions, the preceding one for insertions)
+	a rune
+	// b is a typed symbol (the second correct one for transpositions)
+	b rune
+}
+
+// ErrorModel is a noisy channel model of typing errors.
+// It learns per-character insertion, deletion, substitution and transposition probabilities
+// from correction pairs and estimates P(typo|word)
+type ErrorModel struct {
+	// insertions are counted along with the preceding correct symbol
+	inserts     map[string]int
+	deletes     map[rune]int
+	substitutes map[string]int
+	transposes  map[string]int
+
+	// symbol occurrences in correct words, 0 is the start of a word
+	chars map[rune]int
+}
+
+// untrainedEditProb probability of a single edit used by a model without training data
+const untrainedEditProb = 0.1
+
+// NewErrorModel create an untrained error model. An untrained model estimates probabilities by the number of edits
+func NewErrorModel() *ErrorModel {
+	return &ErrorModel{
+		inserts:     make(map[string]int),
+		deletes:     make(map[rune]int),
+		substitutes: make(map[string]int),
+		transposes:  make(map[string]int),
+		chars:       make(map[rune]int),
+	}
+}
+
+// Train counts edits which turn the correct word into the typo
+func (m *ErrorModel) Train(right, wrong string) {
+	rightRunes := []rune(right)
+	m.chars[0]++
+	for _, r := range rightRunes {
+		m.chars[r]++
+	}
+
+	for _, e := range alignEdits(rightRunes, []rune(wrong)) {
+		switch e.kind {
+		case editInsert:
+			m.inserts[string([]rune{e.a, e.b})]++
+		case editDelete:
+			m.deletes[e.a]++
+		case editSubstitute:
+			m.substitutes[string([]rune{e.a, e.b})]++
+		case editTranspose:
+			m.transposes[string([]rune{e.a, e.b})]++
+		}
+	}
+}
+
+// TrainFrom trains the model with corrections in "right: wrong1 wrong2" format
+func (m *ErrorModel) TrainFrom(r io.Reader) error {
+	corrections, err := ReadCorrections(r)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range corrections {
+		for _, wrong := range c.Wrong {
+			m.Train(c.Right, wrong)
+		}
+	}
+
+	return nil
+}
+
+// Prob estimates P(typo|word): the probability of typing the typo when the word was meant
+func (m *ErrorModel) Prob(typo, word string) float64 {
+	return m.prob([]rune(typo), []rune(word))
+}
+
+func (m *ErrorModel) prob(typo, word []rune) float64 {
+	edits := alignEdits(word, typo)
+	if len(m.chars) == 0 {
+		// add-one smoothing of empty counters gives 1 for every edit, so use a distance-based prior instead
+		return math.Pow(untrainedEditProb, float64(len(edits)))
+	}
+
+	// add-one smoothing
+	v := float64(len(m.chars) + 1)
+
+	p := 1.0
+	for _, e := range edits {
+		switch e.kind {
+		case editInsert:
+			p *= float64(m.inserts[string([]rune{e.a, e.b})]+1) / (float64(m.chars[e.a]) + v)
+		case editDelete:
+			p *= float64(m.deletes[e.a]+1) / (float64(m.chars[e.a]) + v)
+		case editSubstitute:
+			p *= float64(m.substitutes[string([]rune{e.a, e.b})]+1) / (float64(m.chars[e.a]) + v)
+		case editTranspose:
+			p *= float64(m.transposes[string([]rune{e.a, e.b})]+1) / (float64(m.chars[e.a]) + v)
+		}
+	}
+
+	return p
+}
+
+// ScoreFunc returns a score function combining P(typo|word) with the word occurrence counter
+func (m *ErrorModel) ScoreFunc() ScoreFunc {
+	return func(src, candidate []rune, distance, cnt int) float64 {
+		return m.prob(src, candidate) * float64(cnt)
+	}
+}
+
+// WithErrorModel use the noisy channel error model for scoring instead of the default score function.
+// The model is persisted by Save()
+func WithErrorModel(m *ErrorModel) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.errorModel = m
+		s.dict.scoreFunc = m.ScoreFunc()
+		return nil
+	}
+}
+
+// alignEdits finds a minimal sequence of edits (optimal string alignment) turning the correct word into the typed one
+func alignEdits(correct, typed []rune) []edit {
+	n, m := len(correct), len(typed)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+		dp[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dp[0][j] = j
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if correct[i-1] == typed[j-1] {
+				cost = 0
+			}
+			best := dp[i-1][j-1] + cost
+			if v := dp[i-1][j] + 1; v < best {
+				best = v
+			}
+			if v := dp[i][j-1] + 1; v < best {
+				best = v
+			}
+			if i > 1 && j > 1 && correct[i-1] == typed[j-2] && correct[i-2] == typed[j-1] {
+				if v := dp[i-2][j-2] + 1; v < best {
+					best = v
+				}
+			}
+			dp[i][j] = best
+		}
+	}
+
+	var result []edit
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && correct[i-1] == typed[j-1] && dp[i][j] == dp[i-1][j-1]:
+			i, j = i-1, j-1
+		case i > 1 && j > 1 && correct[i-1] == typed[j-2] && correct[i-2] == typed[j-1] && dp[i][j] == dp[i-2][j-2]+1:
+			result = append(result, edit{kind: editTranspose, a: correct[i-2], b: correct[i-1]})
+			i, j = i-2, j-2
+		case i > 0 && j > 0 && dp[i][j] == dp[i-1][j-1]+1:
+			result = append(result, edit{kind: editSubstitute, a: correct[i-1], b: typed[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && dp[i][j] == dp[i-1][j]+1:
+			result = append(result, edit{kind: editDelete, a: correct[i-1]})
+			i--
+		default:
+			var prev rune
+			if i > 0 {
+				prev = correct[i-1]
+			}
+			result = append(result, edit{kind: editInsert, a: prev, b: typed[j-1]})
+			j--
+		}
+	}
+
+	return result
+}
+
+var _ encoding.BinaryMarshaler = (*ErrorModel)(nil)
+var _ encoding.BinaryUnmarshaler = (*ErrorModel)(nil)
+
+type errorModelData struct {
+	Inserts     map[string]int
+	Deletes     map[rune]int
+	Substitutes map[string]int
+	Transposes  map[string]int
+	Chars       map[rune]int
+}
+
+func (m *ErrorModel) MarshalBinary() ([]byte, error) {
+	data := &errorModelData{
+		Inserts:     m.inserts,
+		Deletes:     m.deletes,
+		Substitutes: m.substitutes,
+		Transposes:  m.transposes,
+		Chars:       m.chars,
+	}
+
+	buf := &bytes.Buffer{}
+	err := gob.NewEncoder(buf).Encode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *ErrorModel) UnmarshalBinary(data []byte) error {
+	modelData := &errorModelData{}
+	err := gob.NewDecoder(bytes.NewBuffer(data)).Decode(modelData)
+	if err != nil {
+		return err
+	}
+
+	*m = *NewErrorModel()
+	for k, v := range modelData.Inserts {
+		m.inserts[k] = v
+	}
+	for k, v := range modelData.Deletes {
+		m.deletes[k] = v
+	}
+	for k, v := range modelData.Substitutes {
+		m.substitutes[k] = v
+	}
+	for k, v := range modelData.Transposes {
+		m.transposes[k] = v
+	}
+	for k, v := range modelData.Chars {
+		m.chars[k] = v
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package spellchecker
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadCorrections(t *testing.T) {
+	t.Run("must parse corrections", func(t *testing.T) {
+		result, err := ReadCorrections(bytes.NewBufferString("problem: problam proble\n\nappeal: apeal \n"))
+		require.NoError(t, err)
+		require.Equal(t, []Correction{
+			{Right: "problem", Wrong: []string{"problam", "proble"}},
+			{Right: "appeal", Wrong: []string{"apeal"}},
+		}, result)
+	})
+
+	t.Run("must fail on a line without separator", func(t *testing.T) {
+		_, err := ReadCorrections(bytes.NewBufferString("problem problam"))
+		require.Error(t, err)
+	})
+}
+
+func Test_alignEdits(t *testing.T) {
+	require.Empty(t, alignEdits([]rune("tea"), []rune("tea")))
+	require.Equal(t, []edit{{kind: editTranspose, a: 'a', b: 'n'}}, alignEdits([]rune("orange"), []rune("ornage")))
+	require.Equal(t, []edit{{kind: editSubstitute, a: 'e', b: 'a'}}, alignEdits([]rune("problem"), []rune("problam")))
+	require.Equal(t, []edit{{kind: editDelete, a: 'p'}}, alignEdits([]rune("appeal"), []rune("apeal")))
+	require.Equal(t, []edit{{kind: editInsert, a: 'a', b: 'x'}}, alignEdits([]rune("tea"), []rune("teax")))
+}
+
+func Test_ErrorModel_Prob(t *testing.T) {
+	m := NewErrorModel()
+	for i := 0; i < 10; i++ {
+		m.Train("problem", "problam")
+	}
+	m.Train("range", "rage")
+
+	require.Equal(t, 1.0, m.Prob("tea", "tea"))
+	// learned substitution e => a is more probable than the unseen one e => o
+	require.Greater(t, m.Prob("problam", "problem"), m.Prob("problom", "problem"))
+	require.Greater(t, m.Prob("rage", "range"), m.Prob("rage", "rang"))
+
+	t.Run("must prefer closer words without training data", func(t *testing.T) {
+		m := NewErrorModel()
+		require.Equal(t, 1.0, m.Prob("tea", "tea"))
+		require.Greater(t, m.Prob("orang", "orange"), m.Prob("orang", "range"))
+	})
+}
+
+func Test_ErrorModel_TrainFrom(t *testing.T) {
+	f, err := os.Open("data/norvig1.txt")
+	require.NoError(t, err)
+	defer f.Close()
+
+	m := NewErrorModel()
+	require.NoError(t, m.TrainFrom(f))
+	require.Greater(t, m.chars[0], 0)
+	require.NotEmpty(t, m.substitutes)
+}
+
+func Test_WithErrorModel(t *testing.T) {
+	m := NewErrorModel()
+	for i := 0; i < 10; i++ {
+		m.Train("range", "arang")
+	}
+	for i := 0; i < 100; i++ {
+		m.Train("orange", "orange")
+	}
+
+	s := newSampleSpellchecker()
+	require.NoError(t, s.WithOpts(WithErrorModel(m)))
+
+	result, err := s.Fix("arang")
+	require.NoError(t, err)
+	require.Equal(t, "range", result)
+
+	t.Run("must persist the model", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.Save(buf))
+		s2, err := Load(buf)
+		require.NoError(t, err)
+		require.Equal(t, m.substitutes, s2.errorModel.substitutes)
+
+		result, err := s2.Fix("arang")
+		require.NoError(t, err)
+		require.Equal(t, "range", result)
+	})
+
+	t.Run("must rank closer candidates first with an untrained model", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithErrorModel(NewErrorModel()))
+		require.NoError(t, err)
+		s.Add("orange", "range", "range", "range")
+
+		result, err := s.Suggest("orang", 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"orange", "range"}, result)
+	})
+}
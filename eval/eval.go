@@ -0,0 +1,198 @@
+// Package eval measures spellchecker accuracy and latency on test sets of corrections
+package eval
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/f1monkey/spellchecker"
+)
+
+// DefaultTopK number of suggestions requested for every word
+const DefaultTopK = 10
+
+// Suggester finds top n suggestions for the word (i.e. *spellchecker.Spellchecker or *spellchecker.Layered)
+type Suggester interface {
+	Suggest(word string, n int) ([]string, error)
+}
+
+// Failure a word which top suggestion differs from the expected one
+type Failure struct {
+	Word     string `json:"word"`
+	Expected string `json:"expected"`
+	// Rank 1-based position of the expected word in suggestions, 0 if it was not suggested
+	Rank        int      `json:"rank"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// Latency percentiles of a single Suggest() call
+type Latency struct {
+	P50 time.Duration `json:"p50_ns"`
+	P90 time.Duration `json:"p90_ns"`
+	P99 time.Duration `json:"p99_ns"`
+	Max time.Duration `json:"max_ns"`
+}
+
+// Report evaluation results
+type Report struct {
+	// Total number of checked words
+	Total int `json:"total"`
+	// K number of suggestions requested for every word
+	K int `json:"k"`
+	// Top1 share of words with the expected top suggestion
+	Top1 float64 `json:"top1"`
+	// TopK share of words with the expected word among suggestions
+	TopK float64 `json:"topk"`
+	// MRR mean reciprocal rank of the expected word
+	MRR float64 `json:"mrr"`
+	// UnknownRate share of words without any suggestions
+	UnknownRate float64 `json:"unknown_rate"`
+	Latency     Latency `json:"latency"`
+
+	Failures []Failure `json:"failures"`
+}
+
+// Run evaluates the suggester with the corrections requesting k suggestions for every misspelled word
+func Run(s Suggester, corrections []spellchecker.Correction, k int) *Report {
+	if k <= 0 {
+		k = DefaultTopK
+	}
+
+	report := &Report{K: k}
+	var top1, topK, unknown int
+	var rr float64
+	var durations []time.Duration
+
+	for _, c := range corrections {
+		for _, word := range c.Wrong {
+			start := time.Now()
+			suggestions, err := s.Suggest(word, k)
+			durations = append(durations, time.Since(start))
+			report.Total++
+
+			if errors.Is(err, spellchecker.ErrUnknownWord) {
+				unknown++
+				suggestions = nil
+			}
+
+			rank := 0
+			for i, suggestion := range suggestions {
+				if suggestion == c.Right {
+					rank = i + 1
+					break
+				}
+			}
+			if rank > 0 {
+				topK++
+				rr += 1 / float64(rank)
+			}
+			if rank == 1 {
+				top1++
+				continue
+			}
+
+			report.Failures = append(report.Failures, Failure{
+				Word:        word,
+				Expected:    c.Right,
+				Rank:        rank,
+				Suggestions: suggestions,
+			})
+		}
+	}
+
+	if report.Total > 0 {
+		total := float64(report.Total)
+		report.Top1 = float64(top1) / total
+		report.TopK = float64(topK) / total
+		report.MRR = rr / total
+		report.UnknownRate = float64(unknown) / total
+	}
+	report.Latency = latency(durations)
+
+	return report
+}
+
+// RunFrom reads corrections in "right: wrong1 wrong2" format and evaluates the suggester with them
+func RunFrom(s Suggester, r io.Reader, k int) (*Report, error) {
+	corrections, err := spellchecker.ReadCorrections(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Run(s, corrections, k), nil
+}
+
+func latency(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p int) time.Duration {
+		// nearest-rank method
+		idx := (p*len(durations)+99)/100 - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return durations[idx]
+	}
+
+	return Latency{
+		P50: percentile(50),
+		P90: percentile(90),
+		P99: percentile(99),
+		Max: durations[len(durations)-1],
+	}
+}
+
+// WriteJSON writes the report including failures as JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteCSV writes report metrics as a CSV header and a single row
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{
+		{"total", "k", "top1", "topk", "mrr", "unknown_rate", "latency_p50_ns", "latency_p90_ns", "latency_p99_ns", "latency_max_ns"},
+		{
+			strconv.Itoa(r.Total),
+			strconv.Itoa(r.K),
+			formatFloat(r.Top1),
+			formatFloat(r.TopK),
+			formatFloat(r.MRR),
+			formatFloat(r.UnknownRate),
+			strconv.FormatInt(int64(r.Latency.P50), 10),
+			strconv.FormatInt(int64(r.Latency.P90), 10),
+			strconv.FormatInt(int64(r.Latency.P99), 10),
+			strconv.FormatInt(int64(r.Latency.Max), 10),
+		},
+	}
+
+	return cw.WriteAll(records)
+}
+
+// WriteFailuresCSV writes failures as CSV, suggestions are separated by spaces
+func (r *Report) WriteFailuresCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	records := make([][]string, 0, len(r.Failures)+1)
+	records = append(records, []string{"word", "expected", "rank", "suggestions"})
+	for _, f := range r.Failures {
+		records = append(records, []string{f.Word, f.Expected, strconv.Itoa(f.Rank), strings.Join(f.Suggestions, " ")})
+	}
+
+	return cw.WriteAll(records)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,77 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/stretchr/testify/require"
+)
+
+func newSpellchecker(t *testing.T) *spellchecker.Spellchecker {
+	s, err := spellchecker.New(spellchecker.DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("orange", "orange", "orange", "range", "problem", "green", "tea")
+
+	return s
+}
+
+func Test_RunFrom(t *testing.T) {
+	input := "problem: problam\nrange: arang\ngreen: xyzxyz\n"
+
+	report, err := RunFrom(newSpellchecker(t), strings.NewReader(input), 5)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 5, report.K)
+	require.InDelta(t, 1.0/3, report.Top1, 0.0001)
+	require.InDelta(t, 2.0/3, report.TopK, 0.0001)
+	require.InDelta(t, (1+0.5)/3, report.MRR, 0.0001)
+	require.InDelta(t, 1.0/3, report.UnknownRate, 0.0001)
+	require.Equal(t, []Failure{
+		{Word: "arang", Expected: "range", Rank: 2, Suggestions: []string{"orange", "range"}},
+		{Word: "xyzxyz", Expected: "green", Rank: 0},
+	}, report.Failures)
+	require.LessOrEqual(t, report.Latency.P50, report.Latency.Max)
+}
+
+func Test_latency(t *testing.T) {
+	durations := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i))
+	}
+
+	require.Equal(t, Latency{P50: 50, P90: 90, P99: 99, Max: 100}, latency(durations))
+	require.Equal(t, Latency{}, latency(nil))
+}
+
+func Test_Report_Write(t *testing.T) {
+	report, err := RunFrom(newSpellchecker(t), strings.NewReader("range: arang\n"), 5)
+	require.NoError(t, err)
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteJSON(buf))
+
+		decoded := &Report{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+		require.Equal(t, report, decoded)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteCSV(buf))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		require.True(t, strings.HasPrefix(lines[1], "1,5,0.0000,1.0000,0.5000,0.0000,"))
+	})
+
+	t.Run("failures csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteFailuresCSV(buf))
+		require.Equal(t, "word,expected,rank,suggestions\narang,range,2,orange range\n", buf.String())
+	})
+}
@@ -0,0 +1,56 @@
+package spellchecker
+
+import "sort"
+
+// learned corrections chosen by users: misspelled word => chosen word => times chosen
+type learned map[string]map[string]int
+
+// Feedback records that the user chose the word for the misspelled one.
+// Subsequent Fix and Suggest calls for the misspelled word prefer the most frequently chosen words.
+// Pass the misspelled word itself as chosen to record that the user rejected suggestions and kept the word as is.
+// Other chosen words must be in the dictionary, ErrUnknownWord is returned otherwise
+func (s *Spellchecker) Feedback(misspelled, chosen string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if folded := s.normalize(chosen); folded != s.normalize(misspelled) && !s.known(folded) {
+		return ErrUnknownWord
+	}
+
+	s.feedback(misspelled, chosen)
+	s.journal.writeEntry(opFeedback, misspelled, chosen)
+
+	return nil
+}
+
+func (s *Spellchecker) feedback(misspelled, chosen string) {
+	misspelled, chosen = s.normalize(misspelled), s.normalize(chosen)
+	if s.learned == nil {
+		s.learned = make(learned)
+	}
+	if s.learned[misspelled] == nil {
+		s.learned[misspelled] = make(map[string]int)
+	}
+	s.learned[misspelled][chosen]++
+}
+
+// corrections returns words chosen for the misspelled one, the most frequently chosen first
+func (l learned) corrections(misspelled string) []string {
+	chosen := l[misspelled]
+	if len(chosen) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(chosen))
+	for word := range chosen {
+		result = append(result, word)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if chosen[result[i]] == chosen[result[j]] {
+			return result[i] < result[j]
+		}
+		return chosen[result[i]] > chosen[result[j]]
+	})
+
+	return result
+}
@@ -0,0 +1,83 @@
+package spellchecker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_Feedback(t *testing.T) {
+	t.Run("must prefer the chosen word on Fix", func(t *testing.T) {
+		s := newSampleSpellchecker()
+
+		result, err := s.Fix("arang")
+		require.NoError(t, err)
+		require.Equal(t, "orange", result)
+
+		require.NoError(t, s.Feedback("arang", "range"))
+		result, err = s.Fix("arang")
+		require.NoError(t, err)
+		require.Equal(t, "range", result)
+	})
+
+	t.Run("must put the most frequently chosen words first on Suggest", func(t *testing.T) {
+		s := newSampleSpellchecker()
+		s.Add("arrange")
+		require.NoError(t, s.Feedback("arang", "range"))
+		require.NoError(t, s.Feedback("arang", "arrange"))
+		require.NoError(t, s.Feedback("arang", "arrange"))
+
+		result, err := s.Suggest("arang", 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"arrange", "range", "orange"}, result)
+	})
+
+	t.Run("must keep the word if the user rejected suggestions", func(t *testing.T) {
+		s := newSampleSpellchecker()
+		require.NoError(t, s.Feedback("xyzzy", "xyzzy"))
+
+		result, err := s.Fix("xyzzy")
+		require.NoError(t, err)
+		require.Equal(t, "xyzzy", result)
+	})
+
+	t.Run("must not learn words missing in the dictionary", func(t *testing.T) {
+		s := newSampleSpellchecker()
+		require.ErrorIs(t, s.Feedback("teh", "thx"), ErrUnknownWord)
+
+		result, err := s.Fix("teh")
+		require.NoError(t, err)
+		require.NotEqual(t, "thx", result)
+		suggestions, err := s.Suggest("teh", 2)
+		require.NoError(t, err)
+		require.NotContains(t, suggestions, "thx")
+	})
+
+	t.Run("must persist learned corrections", func(t *testing.T) {
+		s1 := newSampleSpellchecker()
+		require.NoError(t, s1.Feedback("arang", "range"))
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, s1.Save(buf))
+		s2, err := Load(buf)
+		require.NoError(t, err)
+
+		result, err := s2.Fix("arang")
+		require.NoError(t, err)
+		require.Equal(t, "range", result)
+	})
+
+	t.Run("must write feedback to the journal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		s1 := newSampleSpellchecker()
+		require.NoError(t, s1.WithOpts(WithJournal(buf)))
+		require.NoError(t, s1.Feedback("arang", "range"))
+
+		s2 := newSampleSpellchecker()
+		require.NoError(t, s2.Replay(buf))
+		result, err := s2.Fix("arang")
+		require.NoError(t, err)
+		require.Equal(t, "range", result)
+	})
+}
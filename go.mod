@@ -6,6 +6,7 @@ require (
 	github.com/agnivade/levenshtein v1.1.1
 	github.com/f1monkey/bitmap v1.4.0
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -0,0 +1,133 @@
+package spellchecker
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitIdentifier splits camelCase, PascalCase, snake_case and kebab-case identifiers into subwords.
+// Acronyms and numbers are separate subwords: "parseHTTPRequest2" => "parse", "HTTP", "Request", "2"
+func SplitIdentifier(ident string) []string {
+	parts := identifierParts(ident)
+	result := make([]string, len(parts))
+	for i, p := range parts {
+		result[i] = ident[p[0]:p[1]]
+	}
+
+	return result
+}
+
+// identifierParts returns byte ranges of identifier subwords
+func identifierParts(ident string) [][2]int {
+	var result [][2]int
+
+	start := -1
+	var prev rune
+	for i, r := range ident {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				result = append(result, [2]int{start, i})
+				start = -1
+			}
+			prev = r
+			continue
+		}
+
+		if start >= 0 && isSubwordBoundary(prev, r, ident[i+utf8.RuneLen(r):]) {
+			result = append(result, [2]int{start, i})
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+		prev = r
+	}
+	if start >= 0 {
+		result = append(result, [2]int{start, len(ident)})
+	}
+
+	return result
+}
+
+// isSubwordBoundary check if a new subword starts with the symbol r
+func isSubwordBoundary(prev, r rune, rest string) bool {
+	switch {
+	case unicode.IsDigit(prev) != unicode.IsDigit(r):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(r):
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(r):
+		// the last capital letter of an acronym starts a new word: "HTTPServer" => "HTTP", "Server"
+		next, _ := utf8.DecodeRuneInString(rest)
+		return unicode.IsLower(next)
+	}
+
+	return false
+}
+
+// IdentifierTokenizer splits tokens of the inner tokenizer into identifier subwords, so "recieveMessage"
+// is checked as "recieve" and "Message". Subwords without letters are skipped.
+// WordTokenizer is used if inner is nil
+func IdentifierTokenizer(inner Tokenizer) Tokenizer {
+	if inner == nil {
+		inner = WordTokenizer()
+	}
+
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		return inner.Tokenize(r, func(t Token) bool {
+			for _, p := range identifierParts(t.Word) {
+				word := t.Word[p[0]:p[1]]
+				if !hasLetter(word) {
+					continue
+				}
+				if !fn(newToken(word, t.Offset+p[0])) {
+					return false
+				}
+			}
+			return true
+		})
+	})
+}
+
+// minIdentifierSubword subwords shorter than this number of letters (i.e. "x", "id") are not fixed by FixIdentifier
+const minIdentifierSubword = 3
+
+// FixIdentifier fixes misspelled subwords of the identifier and reassembles it preserving separators and case of the subwords,
+// i.e. "recieveMessageHandler" => "receiveMessageHandler", "MAX_RETIRES" => "MAX_RETRIES".
+// Short subwords and acronyms in mixed case identifiers are left unchanged.
+// If a misspelled subword has no suggestions, the original identifier is returned with ErrUnknownWord
+func (s *Spellchecker) FixIdentifier(ident string) (string, error) {
+	mixedCase := strings.ToUpper(ident) != ident
+
+	var sb strings.Builder
+	sb.Grow(len(ident))
+	prev := 0
+	for _, p := range identifierParts(ident) {
+		word := ident[p[0]:p[1]]
+		sb.WriteString(ident[prev:p[0]])
+		prev = p[1]
+
+		if !hasLetter(word) ||
+			utf8.RuneCountInString(word) < minIdentifierSubword ||
+			(mixedCase && strings.ToUpper(word) == word) ||
+			s.IsCorrect(word) {
+			sb.WriteString(word)
+			continue
+		}
+
+		fixed, err := s.Fix(word)
+		if err != nil {
+			return ident, err
+		}
+		sb.WriteString(fixed)
+	}
+	sb.WriteString(ident[prev:])
+
+	return sb.String(), nil
+}
+
+func hasLetter(word string) bool {
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
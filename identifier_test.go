@@ -0,0 +1,70 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SplitIdentifier(t *testing.T) {
+	cases := map[string][]string{
+		"recieveMessageHandler": {"recieve", "Message", "Handler"},
+		"max_retires":           {"max", "retires"},
+		"MAX_RETIRES":           {"MAX", "RETIRES"},
+		"kebab-case-name":       {"kebab", "case", "name"},
+		"parseHTTPRequest2":     {"parse", "HTTP", "Request", "2"},
+		"XMLHttpRequest":        {"XML", "Http", "Request"},
+		"_privateField":         {"private", "Field"},
+		"ёлкаПалка":             {"ёлка", "Палка"},
+	}
+
+	for ident, expected := range cases {
+		t.Run("must split "+ident, func(t *testing.T) {
+			require.Equal(t, expected, SplitIdentifier(ident))
+		})
+	}
+}
+
+func Test_IdentifierTokenizer(t *testing.T) {
+	result := tokenize(IdentifierTokenizer(nil), "call recieveMessage(x86_64)")
+	require.Equal(t, []Token{
+		{Word: "call", Normalized: "call", Offset: 0},
+		{Word: "recieve", Normalized: "recieve", Offset: 5},
+		{Word: "Message", Normalized: "message", Offset: 12},
+		{Word: "x", Normalized: "x", Offset: 20},
+	}, result)
+}
+
+func Test_Spellchecker_FixIdentifier(t *testing.T) {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("receive", "message", "handler", "max", "retries", "parse", "request")
+
+	t.Run("must preserve casing convention", func(t *testing.T) {
+		cases := map[string]string{
+			"recieveMessageHandler": "receiveMessageHandler",
+			"RecieveMesage":         "ReceiveMessage",
+			"max_retires":           "max_retries",
+			"MAX_RETIRES":           "MAX_RETRIES",
+			"max-retires":           "max-retries",
+			"parseHTTPReqest2":      "parseHTTPRequest2",
+		}
+		for ident, expected := range cases {
+			result, err := s.FixIdentifier(ident)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+	})
+
+	t.Run("must keep short subwords", func(t *testing.T) {
+		result, err := s.FixIdentifier("recieveMessage_x")
+		require.NoError(t, err)
+		require.Equal(t, "receiveMessage_x", result)
+	})
+
+	t.Run("must return the original identifier with error for unknown subwords", func(t *testing.T) {
+		result, err := s.FixIdentifier("recieveZzzzzzzz")
+		require.ErrorIs(t, err, ErrUnknownWord)
+		require.Equal(t, "recieveZzzzzzzz", result)
+	})
+}
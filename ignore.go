@@ -0,0 +1,196 @@
+package spellchecker
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+)
+
+// Built-in ignore rule names
+const (
+	IgnoreRuleURL     = "url"
+	IgnoreRuleEmail   = "email"
+	IgnoreRuleNumber  = "number"
+	IgnoreRuleAcronym = "acronym"
+	IgnoreRuleDigits  = "digits"
+	IgnoreRulePath    = "path"
+)
+
+// IgnoreRule skips tokens which must not be checked or added to the dictionary
+type IgnoreRule struct {
+	// Name is reported for tokens skipped by the rule
+	Name string
+	// Span matches parts of a text, all the tokens overlapping them are skipped (i.e. URLs split into several words)
+	Span *regexp.Regexp
+	// Token matches words of single tokens
+	Token *regexp.Regexp
+}
+
+// IgnoreURLs skips URLs with a scheme or starting with "www."
+func IgnoreURLs() IgnoreRule {
+	return IgnoreRule{
+		Name: IgnoreRuleURL,
+		Span: regexp.MustCompile(`(?i)\b(?:[a-z][a-z0-9+.-]*://|www\.)[^\s<>"'()\[\]]+`),
+	}
+}
+
+// IgnoreEmails skips email addresses
+func IgnoreEmails() IgnoreRule {
+	return IgnoreRule{
+		Name: IgnoreRuleEmail,
+		Span: regexp.MustCompile(`[\pL\pN._%+-]+@[\pL\pN-]+(?:\.[\pL\pN-]+)+`),
+	}
+}
+
+// IgnoreNumbers skips decimal and hexadecimal numbers, i.e. "3.14", "0xFF" or "#c0ffee"
+func IgnoreNumbers() IgnoreRule {
+	return IgnoreRule{
+		Name:  IgnoreRuleNumber,
+		Token: regexp.MustCompile(`^(?:[0-9][0-9.,_]*|0[xX][0-9a-fA-F]+|#?[0-9a-fA-F]*[0-9][0-9a-fA-F]*)$`),
+	}
+}
+
+// IgnoreAcronyms skips words written in capital letters, i.e. "HTTP" or "URLs"
+func IgnoreAcronyms() IgnoreRule {
+	return IgnoreRule{
+		Name:  IgnoreRuleAcronym,
+		Token: regexp.MustCompile(`^\p{Lu}{2,}s?$`),
+	}
+}
+
+// IgnoreWordsWithDigits skips words containing digits, i.e. "utf8" or "x86_64"
+func IgnoreWordsWithDigits() IgnoreRule {
+	return IgnoreRule{
+		Name:  IgnoreRuleDigits,
+		Token: regexp.MustCompile(`\pN`),
+	}
+}
+
+// IgnorePaths skips unix and windows file paths, i.e. "/usr/local/bin", "./main.go" or "C:\Windows"
+func IgnorePaths() IgnoreRule {
+	return IgnoreRule{
+		Name: IgnoreRulePath,
+		Span: regexp.MustCompile(`(?:~|\.{1,2})/[\pL\pN._-]+(?:/[\pL\pN._-]+)*/?|[\pL\pN._-]*(?:/[\pL\pN._-]+){2,}/?|\b[a-zA-Z]:\\[\pL\pN._\\-]*`),
+	}
+}
+
+// IgnoreRegexp skips all the tokens overlapping regular expression matches
+func IgnoreRegexp(name string, re *regexp.Regexp) IgnoreRule {
+	return IgnoreRule{
+		Name: name,
+		Span: re,
+	}
+}
+
+// DefaultIgnoreRules returns all the built-in ignore rules
+func DefaultIgnoreRules() []IgnoreRule {
+	return []IgnoreRule{
+		IgnoreURLs(),
+		IgnoreEmails(),
+		IgnorePaths(),
+		IgnoreNumbers(),
+		IgnoreAcronyms(),
+		IgnoreWordsWithDigits(),
+	}
+}
+
+// WithIgnoreRules set rules skipping tokens in text checking and AddFrom()
+func WithIgnoreRules(rules ...IgnoreRule) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.ignoreRules = rules
+		return nil
+	}
+}
+
+// IgnoredToken is a token skipped by an ignore rule
+type IgnoredToken struct {
+	Token
+	// Rule is the name of the rule which skipped the token
+	Rule string
+}
+
+// Ignored splits the text with spellchecker tokenizer and returns tokens skipped by the ignore rules
+func (s *Spellchecker) Ignored(text string) []IgnoredToken {
+	s.mtx.RLock()
+	tokenizer, rules := s.tokenizer, s.ignoreRules
+	s.mtx.RUnlock()
+
+	var result []IgnoredToken
+	tokenize(ignoreTokenizer(tokenizer, rules, func(t Token, rule string) {
+		result = append(result, IgnoredToken{Token: t, Rule: rule})
+	}), text)
+
+	return result
+}
+
+type ignoredSpan struct {
+	start, end int
+	rule       string
+}
+
+// ignoreTokenizer drops tokens matching the rules, skipped is called for every dropped token if not nil.
+// Span rules need the whole text, so the input is read at once if there are any rules
+func ignoreTokenizer(inner Tokenizer, rules []IgnoreRule, skipped func(t Token, rule string)) Tokenizer {
+	if inner == nil {
+		inner = defaultTokenizer
+	}
+	if len(rules) == 0 {
+		return inner
+	}
+
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		text, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		var spans []ignoredSpan
+		for _, rule := range rules {
+			if rule.Span == nil {
+				continue
+			}
+			for _, loc := range rule.Span.FindAllIndex(text, -1) {
+				spans = append(spans, ignoredSpan{start: loc[0], end: loc[1], rule: rule.Name})
+			}
+		}
+		sort.SliceStable(spans, func(i, j int) bool {
+			return spans[i].start < spans[j].start
+		})
+
+		next := 0
+		return inner.Tokenize(bytes.NewReader(text), func(t Token) bool {
+			// tokens go in order, so spans ending before the token are never needed again
+			for next < len(spans) && spans[next].end <= t.Offset {
+				next++
+			}
+			if rule := ignoredBy(t, spans[next:], rules); rule != "" {
+				if skipped != nil {
+					skipped(t, rule)
+				}
+				return true
+			}
+			return fn(t)
+		})
+	})
+}
+
+// ignoredBy returns the name of the first rule skipping the token or an empty string
+func ignoredBy(t Token, spans []ignoredSpan, rules []IgnoreRule) string {
+	end := t.Offset + len(t.Word)
+	for _, span := range spans {
+		if span.start >= end {
+			break
+		}
+		if span.end > t.Offset {
+			return span.rule
+		}
+	}
+	for _, rule := range rules {
+		if rule.Token != nil && rule.Token.MatchString(t.Word) {
+			return rule.Name
+		}
+	}
+
+	return ""
+}
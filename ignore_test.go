@@ -0,0 +1,100 @@
+package spellchecker
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IgnoreRules(t *testing.T) {
+	ignored := func(rule IgnoreRule, text string) []string {
+		var result []string
+		tokenize(ignoreTokenizer(WhitespaceTokenizer(), []IgnoreRule{rule}, func(t Token, _ string) {
+			result = append(result, t.Word)
+		}), text)
+		return result
+	}
+
+	t.Run("must skip URLs", func(t *testing.T) {
+		require.Equal(t,
+			[]string{"https://example.com/a?b=c", "www.example.org"},
+			ignored(IgnoreURLs(), "see https://example.com/a?b=c or www.example.org"),
+		)
+	})
+
+	t.Run("must skip emails", func(t *testing.T) {
+		require.Equal(t, []string{"user.name+tag@example.com"}, ignored(IgnoreEmails(), "mail user.name+tag@example.com now"))
+	})
+
+	t.Run("must skip numbers", func(t *testing.T) {
+		require.Equal(t,
+			[]string{"3.14", "1,000", "0xFF", "c0ffee", "deadbeef1"},
+			ignored(IgnoreNumbers(), "3.14 1,000 0xFF #c0ffee deadbeef1 coffee"),
+		)
+	})
+
+	t.Run("must skip acronyms", func(t *testing.T) {
+		require.Equal(t, []string{"HTTP", "URLs"}, ignored(IgnoreAcronyms(), "HTTP URLs Green A"))
+	})
+
+	t.Run("must skip words with digits", func(t *testing.T) {
+		require.Equal(t, []string{"utf8", "x86_64"}, ignored(IgnoreWordsWithDigits(), "utf8 x86_64 tea"))
+	})
+
+	t.Run("must skip paths", func(t *testing.T) {
+		require.Equal(t,
+			// whitespace tokenizer trims punctuation around words
+			[]string{"usr/local/bin", "main.go", "notes", `C:\Windows\System32`, "src/pkg/main.go"},
+			ignored(IgnorePaths(), `/usr/local/bin ./main.go ~/notes C:\Windows\System32 src/pkg/main.go and/or`),
+		)
+	})
+
+	t.Run("must skip regexp matches", func(t *testing.T) {
+		require.Equal(t, []string{"JIRA-123"}, ignored(IgnoreRegexp("ticket", regexp.MustCompile(`[A-Z]+-\d+`)), "fix JIRA-123 now"))
+	})
+}
+
+func Test_Spellchecker_Ignored(t *testing.T) {
+	s, err := New(DefaultAlphabet, WithTokenizer(WordTokenizer()), WithIgnoreRules(DefaultIgnoreRules()...))
+	require.NoError(t, err)
+
+	result := s.Ignored("Visit https://example.com/tea or mail tea@example.com about HTTP and utf8")
+	require.Equal(t, []IgnoredToken{
+		{Token{Word: "https", Normalized: "https", Offset: 6}, IgnoreRuleURL},
+		{Token{Word: "example.com", Normalized: "example.com", Offset: 14}, IgnoreRuleURL},
+		{Token{Word: "tea", Normalized: "tea", Offset: 26}, IgnoreRuleURL},
+		{Token{Word: "tea", Normalized: "tea", Offset: 38}, IgnoreRuleEmail},
+		{Token{Word: "example.com", Normalized: "example.com", Offset: 42}, IgnoreRuleEmail},
+		{Token{Word: "HTTP", Normalized: "http", Offset: 60}, IgnoreRuleAcronym},
+		{Token{Word: "utf8", Normalized: "utf8", Offset: 69}, IgnoreRuleDigits},
+	}, result)
+}
+
+func Test_Spellchecker_WithIgnoreRules(t *testing.T) {
+	t.Run("must not add ignored words", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithIgnoreRules(IgnoreURLs(), IgnoreEmails()))
+		require.NoError(t, err)
+
+		err = s.AddFrom(strings.NewReader("green tea https://example.com/green user@example.com"))
+		require.NoError(t, err)
+		require.True(t, s.IsCorrect("green"))
+		require.True(t, s.IsCorrect("tea"))
+		require.False(t, s.IsCorrect("https"))
+		require.False(t, s.IsCorrect("user"))
+		require.Equal(t, 1, s.dict.counts[s.dict.id("green")])
+	})
+
+	t.Run("must skip ignored words in text checking", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithTokenizer(WordTokenizer()), WithIgnoreRules(DefaultIgnoreRules()...))
+		require.NoError(t, err)
+		s.Add("green", "tea")
+
+		require.Equal(t,
+			[]Misspelling{{Token{Word: "at", Normalized: "at", Offset: 10}}, {Token{Word: "for", Normalized: "for", Offset: 29}}},
+			s.CheckText("green tea at www.example.com for NASA"),
+		)
+		require.Equal(t, []Token{{Word: "green", Normalized: "green", Offset: 0}}, s.Tokens("green v2"))
+	})
+}
@@ -0,0 +1,129 @@
+// Package ispell implements the "ispell -a" pipe protocol used by editors (Emacs flyspell, Vim, LaTeX tools)
+package ispell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/f1monkey/spellchecker"
+)
+
+// Banner is the first line written by the server
+const Banner = "@(#) International Ispell Version 3.1.20 (but really f1monkey/spellchecker)"
+
+// DefaultMaxSuggestions max number of near misses reported for a word
+const DefaultMaxSuggestions = 10
+
+// Server answers ispell pipe protocol requests with the spellchecker
+type Server struct {
+	Checker *spellchecker.Spellchecker
+	// MaxSuggestions max number of near misses reported for a word, DefaultMaxSuggestions if 0
+	MaxSuggestions int
+	// Save is called on "#" command to persist the personal dictionary. The command is ignored if nil
+	Save func() error
+}
+
+type session struct {
+	*Server
+	w        *bufio.Writer
+	terse    bool
+	accepted map[string]struct{}
+}
+
+// Serve reads requests line by line from r and writes responses to w until r is exhausted
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	sess := &session{
+		Server:   s,
+		w:        bufio.NewWriter(w),
+		accepted: make(map[string]struct{}),
+	}
+
+	fmt.Fprintln(sess.w, Banner)
+	if err := sess.w.Flush(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := sess.handle(scanner.Text()); err != nil {
+			return err
+		}
+		if err := sess.w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (s *session) handle(line string) error {
+	if line == "" {
+		return s.check(line, 0)
+	}
+
+	switch line[0] {
+	case '!':
+		s.terse = true
+	case '%':
+		s.terse = false
+	case '*':
+		s.Checker.Add(line[1:])
+	case '&':
+		s.Checker.Add(strings.ToLower(line[1:]))
+	case '@':
+		s.accepted[line[1:]] = struct{}{}
+	case '#':
+		if s.Save != nil {
+			return s.Save()
+		}
+	case '^':
+		return s.check(line[1:], 1)
+	case '+', '-', '~', '$':
+		// TeX mode switches, formatter selection and other settings are not supported
+	default:
+		return s.check(line, 0)
+	}
+
+	return nil
+}
+
+// check writes a response line for every word of the text followed by an empty line.
+// Word offsets are counted in symbols from the beginning of the request line
+func (s *session) check(text string, shift int) error {
+	maxSuggestions := s.MaxSuggestions
+	if maxSuggestions <= 0 {
+		maxSuggestions = DefaultMaxSuggestions
+	}
+
+	for _, t := range s.Checker.Tokens(text) {
+		if s.isCorrect(t) {
+			if !s.terse {
+				fmt.Fprintln(s.w, "*")
+			}
+			continue
+		}
+
+		offset := utf8.RuneCountInString(text[:t.Offset]) + shift
+		suggestions, err := s.Checker.Suggest(t.Word, maxSuggestions)
+		if err != nil || len(suggestions) == 0 {
+			fmt.Fprintf(s.w, "# %s %d\n", t.Word, offset)
+			continue
+		}
+		fmt.Fprintf(s.w, "& %s %d %d: %s\n", t.Word, len(suggestions), offset, strings.Join(suggestions, ", "))
+	}
+
+	_, err := fmt.Fprintln(s.w)
+
+	return err
+}
+
+func (s *session) isCorrect(t spellchecker.Token) bool {
+	if _, ok := s.accepted[t.Word]; ok {
+		return true
+	}
+
+	return s.Checker.IsCorrect(t.Word)
+}
@@ -0,0 +1,77 @@
+package ispell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *Server {
+	s, err := spellchecker.New(spellchecker.DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("orange", "orange", "range", "green", "tea", "problem")
+
+	return &Server{Checker: s}
+}
+
+func serve(t *testing.T, s *Server, input string) []string {
+	out := &bytes.Buffer{}
+	require.NoError(t, s.Serve(strings.NewReader(input), out))
+
+	return strings.Split(out.String(), "\n")
+}
+
+func Test_Server_Serve(t *testing.T) {
+	t.Run("must respond to every word", func(t *testing.T) {
+		lines := serve(t, newServer(t), "Green tea, arang xyzxyz\n")
+		require.Equal(t, []string{
+			Banner,
+			"*",
+			"*",
+			"& arang 2 11: orange, range",
+			"# xyzxyz 17",
+			"",
+			"",
+		}, lines)
+	})
+
+	t.Run("must suggest the dictionary form of mixed case words", func(t *testing.T) {
+		lines := serve(t, newServer(t), "!\ngrEEn\n")
+		require.Equal(t, []string{Banner, "& grEEn 1 0: green", "", ""}, lines)
+	})
+
+	t.Run("must count offsets from the line start including the escape symbol", func(t *testing.T) {
+		lines := serve(t, newServer(t), "!\n^*tea arang\n")
+		require.Equal(t, []string{Banner, "& arang 2 6: orange, range", "", ""}, lines)
+	})
+
+	t.Run("must add words to the personal dictionary", func(t *testing.T) {
+		saved := false
+		s := newServer(t)
+		s.Save = func() error {
+			saved = true
+			return nil
+		}
+
+		lines := serve(t, s, "!\n*kubernetes\n&Golang\n#\nkubernetes golang Golang\n")
+		require.Equal(t, []string{Banner, "", ""}, lines)
+		require.True(t, saved)
+		require.True(t, s.Checker.IsCorrect("kubernetes"))
+		require.True(t, s.Checker.IsCorrect("golang"))
+	})
+
+	t.Run("must accept words for the session only", func(t *testing.T) {
+		s := newServer(t)
+		lines := serve(t, s, "!\n@xyzxyz\nxyzxyz\n")
+		require.Equal(t, []string{Banner, "", ""}, lines)
+		require.False(t, s.Checker.IsCorrect("xyzxyz"))
+	})
+}
+
+func Test_Server_Serve_offsets(t *testing.T) {
+	lines := serve(t, newServer(t), "!\ncafé arang\n")
+	require.Equal(t, []string{Banner, "# café 0", "& arang 2 5: orange, range", "", ""}, lines)
+}
@@ -0,0 +1,377 @@
+package spellchecker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// JournalSuffix is appended to the snapshot path to get the path of its journal
+const JournalSuffix = ".journal"
+
+var ErrNotOpened = fmt.Errorf("spellchecker was not opened from a file")
+
+var ErrJournalOption = fmt.Errorf("journal option can't be used with Open")
+
+type journalOp byte
+
+const (
+	opAdd      journalOp = '+'
+	opRemove   journalOp = '-'
+	opSet      journalOp = '='
+	opFeedback journalOp = '>'
+	opForm     journalOp = '^'
+	// opGeneration starts a journal of the snapshot generation, it is written by Open() and Compact()
+	opGeneration journalOp = '#'
+)
+
+// journal is an append-only log of dictionary changes.
+// Every entry is a single line: an operation symbol followed by quoted arguments
+type journal struct {
+	w   io.Writer
+	err error
+}
+
+// write appends an entry for every word.
+// Write errors are sticky: once failed, the journal ignores subsequent entries
+func (j *journal) write(op journalOp, words ...string) {
+	if j == nil || j.err != nil || len(words) == 0 {
+		return
+	}
+
+	buf := make([]byte, 0, len(words)*16)
+	for _, word := range words {
+		buf = appendJournalEntry(buf, op, word)
+	}
+
+	j.flush(buf)
+}
+
+// writeEntry appends a single entry with several arguments
+func (j *journal) writeEntry(op journalOp, args ...string) {
+	if j == nil || j.err != nil {
+		return
+	}
+
+	j.flush(appendJournalEntry(nil, op, args...))
+}
+
+// writeCounts appends a counter change entry for every word
+func (j *journal) writeCounts(counts map[string]int) {
+	if j == nil || j.err != nil || len(counts) == 0 {
+		return
+	}
+
+	buf := make([]byte, 0, len(counts)*24)
+	for word, count := range counts {
+		buf = appendJournalEntry(buf, opSet, word, strconv.Itoa(count))
+	}
+
+	j.flush(buf)
+}
+
+func (j *journal) flush(buf []byte) {
+	_, j.err = j.w.Write(buf)
+}
+
+func appendJournalEntry(buf []byte, op journalOp, args ...string) []byte {
+	buf = append(buf, byte(op))
+	for _, arg := range args {
+		buf = append(buf, ' ')
+		buf = strconv.AppendQuote(buf, arg)
+	}
+
+	return append(buf, '\n')
+}
+
+// WithJournal enable journal mode: every dictionary change (Add, Remove, Merge, Feedback) is appended to w.
+// Journal write errors are reported by Compact() and Close()
+func WithJournal(w io.Writer) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.journal = &journal{w: w}
+		return nil
+	}
+}
+
+// Replay reads journal entries from r and applies them to the dictionary.
+// An incomplete trailing entry (i.e. left by a crash) is ignored
+func (s *Spellchecker) Replay(r io.Reader) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	_, err := s.replay(r)
+
+	return err
+}
+
+// replay applies journal entries and returns the number of bytes occupied by complete entries.
+// Entries of a journal of an older generation are already folded into the snapshot:
+// they are skipped and 0 is returned, so the journal may be truncated
+func (s *Spellchecker) replay(r io.Reader) (int64, error) {
+	reader := bufio.NewReader(r)
+
+	var n int64
+	stale := false
+	for {
+		line, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) && stale {
+			return 0, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		op, args, err := parseJournalLine(strings.TrimSuffix(line, "\n"))
+		if err != nil {
+			return n, fmt.Errorf("journal offset %d: %w", n, err)
+		}
+
+		if stale {
+			n += int64(len(line))
+			continue
+		}
+
+		switch op {
+		case opGeneration:
+			if len(args) != 1 {
+				return n, fmt.Errorf("journal offset %d: malformed entry %q", n, line)
+			}
+			generation, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return n, fmt.Errorf("journal offset %d: %w", n, err)
+			}
+			stale = generation < s.generation
+		case opAdd:
+			s.add(args...)
+		case opRemove:
+			s.remove(args...)
+		case opSet:
+			if len(args) != 2 {
+				return n, fmt.Errorf("journal offset %d: malformed entry %q", n, line)
+			}
+			count, err := strconv.Atoi(args[1])
+			if err != nil {
+				return n, fmt.Errorf("journal offset %d: %w", n, err)
+			}
+			s.set(args[0], count)
+		case opFeedback:
+			if len(args) != 2 {
+				return n, fmt.Errorf("journal offset %d: malformed entry %q", n, line)
+			}
+			s.feedback(args[0], args[1])
+		case opForm:
+			s.setForms(args...)
+		default:
+			return n, fmt.Errorf("journal offset %d: unknown operation %q", n, op)
+		}
+		n += int64(len(line))
+	}
+}
+
+func parseJournalLine(line string) (journalOp, []string, error) {
+	if len(line) < 2 || line[1] != ' ' {
+		return 0, nil, fmt.Errorf("malformed entry %q", line)
+	}
+
+	op := journalOp(line[0])
+	rest := line[2:]
+	var args []string
+	for rest != "" {
+		if rest[0] != '"' {
+			arg, tail, _ := strings.Cut(rest, " ")
+			args = append(args, arg)
+			rest = tail
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return 0, nil, fmt.Errorf("malformed entry %q: %w", line, err)
+		}
+		arg, err := strconv.Unquote(quoted)
+		if err != nil {
+			return 0, nil, fmt.Errorf("malformed entry %q: %w", line, err)
+		}
+		args = append(args, arg)
+		rest = strings.TrimPrefix(rest[len(quoted):], " ")
+	}
+
+	return op, args, nil
+}
+
+// LoadFile loads spellchecker from the snapshot file at path and replays the journal stored next to it if there is one.
+// Unlike Open() the journal is not kept open and subsequent changes are not written anywhere
+func LoadFile(path string) (*Spellchecker, error) {
+	snapshot, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+
+	s, err := Load(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path + JournalSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if _, err := s.replay(file); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Open loads spellchecker from the snapshot file at path and replays the journal stored next to it (path + JournalSuffix).
+// Options are applied before the journal is replayed, WithJournal() is not allowed.
+// The journal is kept open: every subsequent dictionary change is appended to it.
+// Call Compact() to fold the journal into a fresh snapshot and Close() to release the journal file
+func Open(path string, opts ...OptionFunc) (*Spellchecker, error) {
+	snapshot, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+
+	s, err := Load(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.WithOpts(opts...); err != nil {
+		return nil, err
+	}
+	if s.journal != nil {
+		return nil, ErrJournalOption
+	}
+
+	file, err := os.OpenFile(path+JournalSuffix, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.replay(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	// drop an incomplete trailing entry so new entries start from a new line
+	if err := file.Truncate(n); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(n, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	s.path = path
+	s.journal = &journal{w: file}
+	if n == 0 {
+		s.journal.writeEntry(opGeneration, strconv.FormatUint(s.generation, 10))
+		if s.journal.err != nil {
+			file.Close()
+			return nil, s.journal.err
+		}
+	}
+
+	return s, nil
+}
+
+// Path returns the snapshot file the spellchecker was opened from, an empty string if it was not opened with Open()
+func (s *Spellchecker) Path() string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.path
+}
+
+// Compact writes a fresh snapshot to the file the spellchecker was opened from and truncates the journal.
+// The snapshot gets a new generation, so the journal is not replayed again if the process stops before truncating it
+func (s *Spellchecker) Compact() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.path == "" || s.journal == nil {
+		return ErrNotOpened
+	}
+	if s.journal.err != nil {
+		return s.journal.err
+	}
+
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	s.generation++
+	if err := s.save(tmp); err != nil {
+		s.generation--
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		s.generation--
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		s.generation--
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		s.generation--
+		return err
+	}
+
+	file, ok := s.journal.w.(*os.File)
+	if !ok {
+		return nil
+	}
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.journal.writeEntry(opGeneration, strconv.FormatUint(s.generation, 10))
+
+	return s.journal.err
+}
+
+// Close closes the journal file opened by Open()
+func (s *Spellchecker) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.journal == nil {
+		return nil
+	}
+
+	err := s.journal.err
+	if c, ok := s.journal.w.(io.Closer); ok && s.path != "" {
+		if cErr := c.Close(); err == nil {
+			err = cErr
+		}
+	}
+	s.journal = nil
+	s.path = ""
+
+	return err
+}
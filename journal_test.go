@@ -0,0 +1,153 @@
+package spellchecker
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_Replay(t *testing.T) {
+	t.Run("must restore changes written to the journal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		s1, err := New(DefaultAlphabet, WithJournal(buf))
+		require.NoError(t, err)
+
+		s1.Add("orange", "orange", "apple", "new\nline")
+		s1.Remove("apple")
+
+		s2, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		require.NoError(t, s2.Replay(buf))
+
+		require.True(t, s2.IsCorrect("orange"))
+		require.True(t, s2.IsCorrect("new\nline"))
+		require.False(t, s2.IsCorrect("apple"))
+		require.Equal(t, 2, s2.dict.counts[s2.dict.id("orange")])
+	})
+
+	t.Run("must ignore an incomplete trailing entry", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+
+		err = s.Replay(bytes.NewBufferString("+ \"orange\"\n+ \"app"))
+		require.NoError(t, err)
+		require.True(t, s.IsCorrect("orange"))
+		require.False(t, s.IsCorrect("app"))
+	})
+
+	t.Run("must fail on a malformed entry", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+
+		err = s.Replay(bytes.NewBufferString("? \"orange\"\n"))
+		require.Error(t, err)
+	})
+}
+
+func Test_Open(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "spellchecker.bin")
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	require.NoError(t, newSampleSpellchecker().Save(file))
+	require.NoError(t, file.Close())
+
+	s, err := Open(filePath)
+	require.NoError(t, err)
+	s.Add("banana")
+	s.Remove("orange")
+	require.NoError(t, s.Close())
+
+	t.Run("must replay the journal stored next to the snapshot", func(t *testing.T) {
+		s, err := Open(filePath)
+		require.NoError(t, err)
+		defer s.Close()
+
+		require.True(t, s.IsCorrect("banana"))
+		require.False(t, s.IsCorrect("orange"))
+		require.True(t, s.IsCorrect("green"))
+	})
+
+	t.Run("must fold the journal into the snapshot on compaction", func(t *testing.T) {
+		s, err := Open(filePath)
+		require.NoError(t, err)
+		require.NoError(t, s.Compact())
+		require.NoError(t, s.Close())
+
+		data, err := os.ReadFile(filePath + JournalSuffix)
+		require.NoError(t, err)
+		require.Equal(t, "# \"1\"\n", string(data))
+
+		s, err = Open(filePath)
+		require.NoError(t, err)
+		defer s.Close()
+		require.True(t, s.IsCorrect("banana"))
+		require.False(t, s.IsCorrect("orange"))
+	})
+
+	t.Run("must not replay the journal folded by an interrupted compaction", func(t *testing.T) {
+		s, err := Open(filePath)
+		require.NoError(t, err)
+		s.Add("kiwi", "kiwi")
+		require.Equal(t, 2, s.Count("kiwi"))
+		journal, err := os.ReadFile(filePath + JournalSuffix)
+		require.NoError(t, err)
+		require.NoError(t, s.Compact())
+		require.NoError(t, s.Close())
+
+		// the snapshot is replaced, but the journal is not truncated yet
+		require.NoError(t, os.WriteFile(filePath+JournalSuffix, journal, 0o644))
+
+		s, err = Open(filePath)
+		require.NoError(t, err)
+		require.Equal(t, 2, s.Count("kiwi"))
+		s.Add("kiwi")
+		require.NoError(t, s.Close())
+
+		s, err = LoadFile(filePath)
+		require.NoError(t, err)
+		require.Equal(t, 3, s.Count("kiwi"))
+	})
+
+	t.Run("must apply options before replaying the journal", func(t *testing.T) {
+		s, err := Open(filePath)
+		require.NoError(t, err)
+		s.Add("café")
+		require.NoError(t, s.Close())
+
+		s, err = Open(filePath, WithNormalization(Normalization{FoldAccents: true}))
+		require.NoError(t, err)
+		defer s.Close()
+		require.True(t, s.IsCorrect("cafe"))
+	})
+
+	t.Run("must reject the journal option", func(t *testing.T) {
+		_, err := Open(filePath, WithJournal(&bytes.Buffer{}))
+		require.ErrorIs(t, err, ErrJournalOption)
+	})
+
+	t.Run("must not compact spellchecker which was not opened from a file", func(t *testing.T) {
+		require.ErrorIs(t, newSampleSpellchecker().Compact(), ErrNotOpened)
+	})
+}
+
+func Test_LoadFile(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "spellchecker.bin")
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	require.NoError(t, newSampleSpellchecker().Save(file))
+	require.NoError(t, file.Close())
+
+	s, err := LoadFile(filePath)
+	require.NoError(t, err)
+	require.True(t, s.IsCorrect("orange"))
+	_, err = os.Stat(filePath + JournalSuffix)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(filePath+JournalSuffix, []byte("- \"orange\"\n"), 0o644))
+	s, err = LoadFile(filePath)
+	require.NoError(t, err)
+	require.False(t, s.IsCorrect("orange"))
+}
@@ -0,0 +1,139 @@
+package spellchecker
+
+import (
+	"sync"
+)
+
+// Layered combines a read-only base spellchecker with mutable overlays (personal words, per-project words etc.)
+// and an ignore list. Queries are performed against all the layers, the base dictionary is never copied
+type Layered struct {
+	mtx sync.RWMutex
+
+	base     *Spellchecker
+	overlays []*Spellchecker
+	ignored  map[string]struct{}
+}
+
+// NewLayered create a layered spellchecker on top of the base one
+func NewLayered(base *Spellchecker, overlays ...*Spellchecker) *Layered {
+	return &Layered{
+		base:     base,
+		overlays: overlays,
+		ignored:  make(map[string]struct{}),
+	}
+}
+
+// AddOverlay appends overlays to the layered spellchecker
+func (l *Layered) AddOverlay(overlays ...*Spellchecker) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.overlays = append(l.overlays, overlays...)
+}
+
+// Ignore adds words to the ignore list. Ignored words are considered correct in any case but are never suggested.
+// Words are normalized with the base spellchecker normalization
+func (l *Layered) Ignore(words ...string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for _, word := range words {
+		l.ignored[l.base.normalize(word)] = struct{}{}
+	}
+}
+
+// Unignore removes words from the ignore list
+func (l *Layered) Unignore(words ...string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for _, word := range words {
+		delete(l.ignored, l.base.normalize(word))
+	}
+}
+
+// isIgnored check if the normalized word is in the ignore list
+func (l *Layered) isIgnored(normalized string) bool {
+	_, ok := l.ignored[normalized]
+	return ok
+}
+
+// IsCorrect check if provided word is ignored or present in any of the layers
+func (l *Layered) IsCorrect(word string) bool {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	return l.isCorrect(word)
+}
+
+func (l *Layered) isCorrect(word string) bool {
+	if l.isIgnored(l.base.normalize(word)) {
+		return true
+	}
+	if l.base.IsCorrect(word) {
+		return true
+	}
+	for _, o := range l.overlays {
+		if o.IsCorrect(word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fix find the best suggestion across all the layers
+func (l *Layered) Fix(word string) (string, error) {
+	result, err := l.Suggest(word, 1)
+	if err != nil {
+		return word, err
+	}
+
+	return result[0], nil
+}
+
+// Suggest find top n suggestions for the word across all the layers.
+// Candidates found in several layers are ranked by their best score
+func (l *Layered) Suggest(word string, n int) ([]string, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	if l.isCorrect(word) {
+		return []string{word}, nil
+	}
+
+	// every ignored word may take a place among the top candidates of a layer, so request more of them
+	limit := n + len(l.ignored)
+	scores := make(map[string]float64, limit)
+	collect := func(s *Spellchecker) {
+		for _, m := range s.matches(word, limit, l.isIgnored) {
+			if score, ok := scores[m.Value]; !ok || m.Score > score {
+				scores[m.Value] = m.Score
+			}
+		}
+	}
+	collect(l.base)
+	for _, o := range l.overlays {
+		collect(o)
+	}
+
+	if len(scores) == 0 {
+		return []string{word}, ErrUnknownWord
+	}
+
+	hits := make([]match, 0, len(scores))
+	for value, score := range scores {
+		hits = append(hits, match{Value: value, Score: score})
+	}
+	sortMatches(hits)
+	if len(hits) > n {
+		hits = hits[:n]
+	}
+
+	result := make([]string, len(hits))
+	for i, h := range hits {
+		result[i] = h.Value
+	}
+
+	return result, nil
+}
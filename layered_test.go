@@ -0,0 +1,89 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Layered_IsCorrect(t *testing.T) {
+	personal := newTestSpellchecker(t, "oranges", "kubernetes")
+	l := NewLayered(newSampleSpellchecker(), personal)
+
+	require.True(t, l.IsCorrect("orange"))
+	require.True(t, l.IsCorrect("kubernetes"))
+	require.False(t, l.IsCorrect("golang"))
+
+	personal.Add("golang")
+	require.True(t, l.IsCorrect("golang"))
+
+	l.Ignore("teh")
+	require.True(t, l.IsCorrect("teh"))
+	l.Unignore("teh")
+	require.False(t, l.IsCorrect("teh"))
+}
+
+func Test_Layered_Suggest(t *testing.T) {
+	l := NewLayered(newSampleSpellchecker(), newTestSpellchecker(t, "oranges", "kubernetes"))
+
+	t.Run("must merge candidates from all the layers", func(t *testing.T) {
+		result, err := l.Suggest("arang", 5)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"orange", "range"}, result)
+
+		result, err = l.Suggest("kubernets", 5)
+		require.NoError(t, err)
+		require.Equal(t, []string{"kubernetes"}, result)
+	})
+
+	t.Run("must not suggest ignored words", func(t *testing.T) {
+		l.Ignore("range")
+		defer l.Unignore("range")
+
+		result, err := l.Suggest("arang", 5)
+		require.NoError(t, err)
+		require.Equal(t, []string{"orange"}, result)
+	})
+
+	t.Run("must ignore words in any case", func(t *testing.T) {
+		l.Ignore("Orange")
+		defer l.Unignore("orange")
+
+		result, err := l.Suggest("Orang", 5)
+		require.NoError(t, err)
+		require.NotContains(t, result, "Orange")
+		require.Contains(t, result, "Range")
+
+		l.Ignore("Teh")
+		defer l.Unignore("teh")
+		require.True(t, l.IsCorrect("teh"))
+		require.True(t, l.IsCorrect("TEH"))
+	})
+
+	t.Run("must fill top n after skipping ignored words", func(t *testing.T) {
+		top, err := l.Suggest("arang", 2)
+		require.NoError(t, err)
+		require.Len(t, top, 2)
+
+		l.Ignore(top[0])
+		defer l.Unignore(top[0])
+
+		result, err := l.Suggest("arang", 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{top[1]}, result)
+	})
+
+	t.Run("must return an error for unknown words", func(t *testing.T) {
+		result, err := l.Suggest("xyzxyz", 5)
+		require.ErrorIs(t, err, ErrUnknownWord)
+		require.Equal(t, []string{"xyzxyz"}, result)
+	})
+
+	t.Run("must not modify the base", func(t *testing.T) {
+		l.AddOverlay(newSampleSpellchecker())
+		fixed, err := l.Fix("problam")
+		require.NoError(t, err)
+		require.Equal(t, "problem", fixed)
+		require.False(t, l.base.IsCorrect("kubernetes"))
+	})
+}
@@ -0,0 +1,82 @@
+package spellchecker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyboardLayout is a pair of keyboard layouts: symbols of A and B at the same positions are typed with the same keys
+type KeyboardLayout struct {
+	A string
+	B string
+}
+
+// LayoutEnRu is the QWERTY/ЙЦУКЕН layout pair: "ghbdtn" => "привет", "руддщ" => "hello"
+var LayoutEnRu = KeyboardLayout{
+	A: "`qwertyuiop[]asdfghjkl;'zxcvbnm,.",
+	B: "ёйцукенгшщзхъфывапролджэячсмитьбю",
+}
+
+// layoutMap maps symbols typed with a wrong layout to the symbols of the right one
+type layoutMap map[rune]rune
+
+// WithKeyboardLayouts enable keyboard layout switch detection in Fix() and Suggest():
+// an unknown word is remapped through the layout pairs in both directions and checked against the dictionary
+// before falling back to the edit distance search
+func WithKeyboardLayouts(layouts ...KeyboardLayout) OptionFunc {
+	return func(s *Spellchecker) error {
+		maps := make([]layoutMap, 0, len(layouts)*2)
+		for _, l := range layouts {
+			a, b := []rune(strings.ToLower(l.A)), []rune(strings.ToLower(l.B))
+			if len(a) != len(b) {
+				return fmt.Errorf("keyboard layouts %q and %q have different number of symbols", l.A, l.B)
+			}
+
+			ab, ba := make(layoutMap, len(a)), make(layoutMap, len(b))
+			for i := range a {
+				ab[a[i]] = b[i]
+				ba[b[i]] = a[i]
+			}
+			maps = append(maps, ab, ba)
+		}
+		s.layouts = maps
+
+		return nil
+	}
+}
+
+// switchLayout returns dictionary words matching the word typed with a wrong keyboard layout
+func (s *Spellchecker) switchLayout(word string) []string {
+	if len(s.layouts) == 0 {
+		return nil
+	}
+
+	lower := strings.ToLower(word)
+	var result []string
+	for _, m := range s.layouts {
+		switched, ok := m.remap(lower)
+		if !ok {
+			continue
+		}
+		if key := s.normalize(switched); s.dict.has(key) {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// remap replaces every symbol of the word, fails if some symbol is missing in the layout
+func (m layoutMap) remap(word string) (string, bool) {
+	var sb strings.Builder
+	sb.Grow(len(word) * 2)
+	for _, r := range word {
+		switched, ok := m[r]
+		if !ok {
+			return "", false
+		}
+		sb.WriteRune(switched)
+	}
+
+	return sb.String(), word != ""
+}
@@ -0,0 +1,53 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithKeyboardLayouts(t *testing.T) {
+	newLayoutSpellchecker := func(t *testing.T) *Spellchecker {
+		s, err := New(DefaultAlphabet+"абвгдеёжзийклмнопрстуфхцчшщъыьэюя", WithKeyboardLayouts(LayoutEnRu))
+		require.NoError(t, err)
+		s.Add("привет", "hello", "люблю", "ghost")
+		return s
+	}
+
+	t.Run("must fail on layouts of different length", func(t *testing.T) {
+		_, err := New(DefaultAlphabet, WithKeyboardLayouts(KeyboardLayout{A: "abc", B: "аб"}))
+		require.Error(t, err)
+	})
+
+	t.Run("must fix words typed with a wrong layout", func(t *testing.T) {
+		s := newLayoutSpellchecker(t)
+		for input, expected := range map[string]string{"ghbdtn": "привет", "Ghbdtn": "Привет", "руддщ": "hello", "k.,k.": "люблю"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+	})
+
+	t.Run("must suggest words typed with a wrong layout first", func(t *testing.T) {
+		s := newLayoutSpellchecker(t)
+		result, err := s.Suggest("ghbdtn", 3)
+		require.NoError(t, err)
+		require.Equal(t, "привет", result[0])
+	})
+
+	t.Run("must fall back to edit distance search", func(t *testing.T) {
+		s := newLayoutSpellchecker(t)
+		result, err := s.Fix("helo")
+		require.NoError(t, err)
+		require.Equal(t, "hello", result)
+	})
+
+	t.Run("must not switch layout without configured layouts", func(t *testing.T) {
+		s, err := New(DefaultAlphabet + "абвгдеёжзийклмнопрстуфхцчшщъыьэюя")
+		require.NoError(t, err)
+		s.Add("привет")
+		result, err := s.Fix("ghbdtn")
+		require.ErrorIs(t, err, ErrUnknownWord)
+		require.Equal(t, "ghbdtn", result)
+	})
+}
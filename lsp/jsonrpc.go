@@ -0,0 +1,106 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+)
+
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// maxMessageSize max size of an incoming message body in bytes
+const maxMessageSize = 64 << 20
+
+// maxDiscardSize max size of an oversized message body skipped to read the next message.
+// Larger messages stop the server
+const maxDiscardSize = 1 << 30
+
+// request is an incoming request or notification (without ID)
+type request struct {
+	ID     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+// readRequest reads a single message framed with a Content-Length header
+func readRequest(r *bufio.Reader) (*request, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+	}
+	if length < 0 || length > maxDiscardSize {
+		// the end of the body is unknown, so the next message can't be found
+		return nil, fmt.Errorf("invalid Content-Length %d: out of range 0..%d", length, maxDiscardSize)
+	}
+	if length > maxMessageSize {
+		if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+			return nil, err
+		}
+		return nil, &responseError{
+			Code:    codeParseError,
+			Message: fmt.Sprintf("Content-Length %d is out of range 0..%d", length, maxMessageSize),
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	req := &request{}
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+
+	return req, nil
+}
+
+// writeMessage writes a message framed with a Content-Length header
+func writeMessage(w io.Writer, msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+
+	return err
+}
@@ -0,0 +1,320 @@
+// Package lsp implements a Language Server Protocol server (JSON-RPC over stdio)
+// publishing misspelled words as diagnostics
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/f1monkey/spellchecker"
+)
+
+// Source is the diagnostics source name
+const Source = "spellchecker"
+
+// CommandAddToDictionary adds the word passed as the first argument to the dictionary and persists it
+const CommandAddToDictionary = "spellchecker.addToDictionary"
+
+// DefaultMaxSuggestions max number of replacements offered for a word
+const DefaultMaxSuggestions = 5
+
+// Server answers LSP requests with the spellchecker
+type Server struct {
+	Checker *spellchecker.Spellchecker
+	// MaxSuggestions max number of replacements offered for a word, DefaultMaxSuggestions if 0
+	MaxSuggestions int
+	// Save is called after a word was added to the dictionary. Nothing is persisted if nil
+	Save func() error
+}
+
+type session struct {
+	*Server
+	w    io.Writer
+	docs map[string]string
+}
+
+// Serve reads requests from r and writes responses and notifications to w until "exit" notification is received
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	sess := &session{
+		Server: s,
+		w:      w,
+		docs:   make(map[string]string),
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		req, err := readRequest(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var rErr *responseError
+		if errors.As(err, &rErr) {
+			if err := writeMessage(w, response{JSONRPC: "2.0", Error: rErr}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := sess.handle(req)
+		if req.ID == nil {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		resp := response{JSONRPC: "2.0", ID: req.ID}
+		if err != nil {
+			if !errors.As(err, &rErr) {
+				rErr = &responseError{Code: codeInternalError, Message: err.Error()}
+			}
+			resp.Error = rErr
+		} else if resp.Result, err = json.Marshal(result); err != nil {
+			return err
+		}
+
+		if err := writeMessage(w, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) handle(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				CodeActionProvider: true,
+				ExecuteCommandProvider: executeCommandProvider{
+					Commands: []string{CommandAddToDictionary},
+				},
+			},
+			ServerInfo: serverInfo{Name: Source},
+		}, nil
+
+	case "textDocument/didOpen":
+		params := didOpenParams{}
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publish(params.TextDocument.URI)
+
+	case "textDocument/didChange":
+		params := didChangeParams{}
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// full document sync: the last change contains the whole text
+		s.docs[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, s.publish(params.TextDocument.URI)
+
+	case "textDocument/didClose":
+		params := didCloseParams{}
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, writeMessage(s.w, notification{
+			JSONRPC: "2.0",
+			Method:  "textDocument/publishDiagnostics",
+			Params:  publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}},
+		})
+
+	case "textDocument/codeAction":
+		params := codeActionParams{}
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(params), nil
+
+	case "workspace/executeCommand":
+		params := executeCommandParams{}
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.execute(params)
+
+	case "initialized", "shutdown":
+		return nil, nil
+	}
+
+	if req.ID == nil {
+		// unknown notifications are ignored
+		return nil, nil
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+}
+
+func (s *session) publish(uri string) error {
+	text := s.docs[uri]
+
+	diagnostics := []diagnostic{}
+	for _, m := range s.Checker.CheckTextWith(text, spellchecker.MarkupTokenizer(uri, nil)) {
+		diagnostics = append(diagnostics, diagnostic{
+			Range: lspRange{
+				Start: offsetToPosition(text, m.Offset),
+				End:   offsetToPosition(text, m.Offset+len(m.Word)),
+			},
+			Severity: severityInformation,
+			Source:   Source,
+			Message:  fmt.Sprintf("Unknown word %q", m.Word),
+		})
+	}
+
+	return writeMessage(s.w, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
+
+func (s *session) codeActions(params codeActionParams) []codeAction {
+	maxSuggestions := s.MaxSuggestions
+	if maxSuggestions <= 0 {
+		maxSuggestions = DefaultMaxSuggestions
+	}
+
+	text, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return []codeAction{}
+	}
+
+	actions := []codeAction{}
+	for _, d := range params.Context.Diagnostics {
+		if d.Source != Source {
+			continue
+		}
+		start, end := positionToOffset(text, d.Range.Start), positionToOffset(text, d.Range.End)
+		if start >= end {
+			continue
+		}
+		word := text[start:end]
+
+		suggestions, err := s.Checker.Suggest(word, maxSuggestions)
+		if err == nil {
+			for _, suggestion := range suggestions {
+				actions = append(actions, codeAction{
+					Title:       fmt.Sprintf("Replace with %q", suggestion),
+					Kind:        codeActionQuickFix,
+					Diagnostics: []diagnostic{d},
+					Edit: &workspaceEdit{
+						Changes: map[string][]textEdit{
+							params.TextDocument.URI: {{Range: d.Range, NewText: suggestion}},
+						},
+					},
+				})
+			}
+		}
+
+		actions = append(actions, codeAction{
+			Title:       fmt.Sprintf("Add %q to dictionary", word),
+			Kind:        codeActionQuickFix,
+			Diagnostics: []diagnostic{d},
+			Command: &command{
+				Title:     fmt.Sprintf("Add %q to dictionary", word),
+				Command:   CommandAddToDictionary,
+				Arguments: []interface{}{word},
+			},
+		})
+	}
+
+	return actions
+}
+
+func (s *session) execute(params executeCommandParams) error {
+	if params.Command != CommandAddToDictionary {
+		return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown command %q", params.Command)}
+	}
+	if len(params.Arguments) != 1 {
+		return &responseError{Code: codeInvalidParams, Message: "a single word is expected"}
+	}
+
+	s.Checker.Add(strings.ToLower(params.Arguments[0]))
+	if s.Save != nil {
+		if err := s.Save(); err != nil {
+			return err
+		}
+	}
+
+	for uri := range s.docs {
+		if err := s.publish(uri); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeParams(req *request, v interface{}) error {
+	if err := json.Unmarshal(req.Params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// offsetToPosition converts a byte offset to a position with UTF-16 based character offset
+func offsetToPosition(text string, offset int) position {
+	line := strings.Count(text[:offset], "\n")
+	lineStart := strings.LastIndex(text[:offset], "\n") + 1
+
+	return position{Line: line, Character: utf16Len(text[lineStart:offset])}
+}
+
+// positionToOffset converts a position with UTF-16 based character offset to a byte offset
+func positionToOffset(text string, pos position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+
+	for units := 0; units < pos.Character && offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+		units += utf16RuneLen(r)
+		offset += size
+	}
+
+	return offset
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16RuneLen(r)
+	}
+
+	return n
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+
+	return 1
+}
@@ -0,0 +1,222 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/stretchr/testify/require"
+)
+
+const uri = "file:///tmp/doc.txt"
+
+func newServer(t *testing.T) *Server {
+	s, err := spellchecker.New(spellchecker.DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("orange", "orange", "range", "green", "tea", "problem")
+
+	return &Server{Checker: s}
+}
+
+type outMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func serve(t *testing.T, s *Server, requests ...interface{}) []outMessage {
+	in := &bytes.Buffer{}
+	for _, r := range requests {
+		require.NoError(t, writeMessage(in, r))
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, s.Serve(in, out))
+
+	var result []outMessage
+	reader := bufio.NewReader(out)
+	for {
+		headers, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err == io.EOF {
+			return result
+		}
+		require.NoError(t, err)
+		length, err := strconv.Atoi(headers.Get("Content-Length"))
+		require.NoError(t, err)
+		body := make([]byte, length)
+		_, err = io.ReadFull(reader, body)
+		require.NoError(t, err)
+
+		msg := outMessage{}
+		require.NoError(t, json.Unmarshal(body, &msg))
+		result = append(result, msg)
+	}
+}
+
+func req(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notify(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func didOpen(text string) map[string]interface{} {
+	return notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "plaintext", "version": 1, "text": text},
+	})
+}
+
+func Test_Server_Serve(t *testing.T) {
+	t.Run("must initialize and shut down", func(t *testing.T) {
+		out := serve(t, newServer(t),
+			req(1, "initialize", map[string]interface{}{}),
+			notify("initialized", map[string]interface{}{}),
+			req(2, "shutdown", nil),
+			notify("exit", nil),
+		)
+		require.Len(t, out, 2)
+
+		result := initializeResult{}
+		require.NoError(t, json.Unmarshal(out[0].Result, &result))
+		require.True(t, result.Capabilities.CodeActionProvider)
+		require.Equal(t, []string{CommandAddToDictionary}, result.Capabilities.ExecuteCommandProvider.Commands)
+
+		require.Equal(t, 2, *out[1].ID)
+		require.Equal(t, "null", string(out[1].Result))
+	})
+
+	t.Run("must publish diagnostics", func(t *testing.T) {
+		out := serve(t, newServer(t), didOpen("green tea\n😀 arang"))
+		require.Len(t, out, 1)
+		require.Equal(t, "textDocument/publishDiagnostics", out[0].Method)
+
+		params := publishDiagnosticsParams{}
+		require.NoError(t, json.Unmarshal(out[0].Params, &params))
+		require.Equal(t, []diagnostic{{
+			Range: lspRange{
+				Start: position{Line: 1, Character: 3},
+				End:   position{Line: 1, Character: 8},
+			},
+			Severity: severityInformation,
+			Source:   Source,
+			Message:  `Unknown word "arang"`,
+		}}, params.Diagnostics)
+	})
+
+	t.Run("must offer code actions", func(t *testing.T) {
+		d := diagnostic{
+			Range:  lspRange{Start: position{Line: 0, Character: 6}, End: position{Line: 0, Character: 11}},
+			Source: Source,
+		}
+		out := serve(t, newServer(t),
+			didOpen("green arang"),
+			req(1, "textDocument/codeAction", codeActionParams{
+				TextDocument: textDocumentIdentifier{URI: uri},
+				Range:        d.Range,
+				Context:      codeActionContext{Diagnostics: []diagnostic{d}},
+			}),
+		)
+		require.Len(t, out, 2)
+
+		actions := []codeAction{}
+		require.NoError(t, json.Unmarshal(out[1].Result, &actions))
+		require.Len(t, actions, 3)
+		require.Equal(t, "orange", actions[0].Edit.Changes[uri][0].NewText)
+		require.Equal(t, "range", actions[1].Edit.Changes[uri][0].NewText)
+		require.Equal(t, CommandAddToDictionary, actions[2].Command.Command)
+		require.Equal(t, []interface{}{"arang"}, actions[2].Command.Arguments)
+	})
+
+	t.Run("must add words to dictionary", func(t *testing.T) {
+		saved := false
+		s := newServer(t)
+		s.Save = func() error {
+			saved = true
+			return nil
+		}
+
+		out := serve(t, s,
+			didOpen("green arang"),
+			req(1, "workspace/executeCommand", executeCommandParams{
+				Command:   CommandAddToDictionary,
+				Arguments: []string{"arang"},
+			}),
+		)
+		require.True(t, saved)
+		require.True(t, s.Checker.IsCorrect("arang"))
+		require.Len(t, out, 3)
+
+		params := publishDiagnosticsParams{}
+		require.NoError(t, json.Unmarshal(out[1].Params, &params))
+		require.Empty(t, params.Diagnostics)
+		require.Nil(t, out[2].Error)
+	})
+
+	t.Run("must respond with error to unknown requests", func(t *testing.T) {
+		out := serve(t, newServer(t), req(1, "textDocument/hover", nil), notify("$/cancelRequest", nil))
+		require.Len(t, out, 1)
+		require.Equal(t, codeMethodNotFound, out[0].Error.Code)
+	})
+}
+
+func Test_readRequest(t *testing.T) {
+	t.Run("must read a message", func(t *testing.T) {
+		r := bufio.NewReader(bytes.NewBufferString("Content-Length: 17\r\n\r\n{\"method\":\"exit\"}"))
+		result, err := readRequest(r)
+		require.NoError(t, err)
+		require.Equal(t, "exit", result.Method)
+	})
+
+	t.Run("must skip the body of an oversized message", func(t *testing.T) {
+		body := strings.Repeat(" ", maxMessageSize+1)
+		r := bufio.NewReader(bytes.NewBufferString("Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body))
+		_, err := readRequest(r)
+		rErr := &responseError{}
+		require.ErrorAs(t, err, &rErr)
+		require.Equal(t, codeParseError, rErr.Code)
+
+		_, err = r.Peek(1)
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	for name, length := range map[string]string{
+		"negative": "-1",
+		"huge":     "1099511627776",
+	} {
+		t.Run("must fail on "+name+" length", func(t *testing.T) {
+			r := bufio.NewReader(bytes.NewBufferString("Content-Length: " + length + "\r\n\r\n{}"))
+			_, err := readRequest(r)
+			require.Error(t, err)
+			rErr := &responseError{}
+			require.False(t, errors.As(err, &rErr))
+		})
+	}
+
+	t.Run("must keep serving after an oversized message", func(t *testing.T) {
+		body := `{"jsonrpc":"2.0","method":"initialized","params":{}}` + strings.Repeat(" ", maxMessageSize)
+		in := bytes.NewBufferString("Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body)
+		require.NoError(t, writeMessage(in, req(1, "shutdown", nil)))
+		out := &bytes.Buffer{}
+		require.NoError(t, newServer(t).Serve(in, out))
+		require.Contains(t, out.String(), "out of range")
+		require.Contains(t, out.String(), `"id":1`)
+	})
+}
+
+func Test_positions(t *testing.T) {
+	text := "ab\n😀cd\n"
+
+	require.Equal(t, position{Line: 1, Character: 2}, offsetToPosition(text, 7))
+	require.Equal(t, 7, positionToOffset(text, position{Line: 1, Character: 2}))
+	require.Equal(t, 9, positionToOffset(text, position{Line: 1, Character: 100}))
+	require.Equal(t, len(text), positionToOffset(text, position{Line: 5, Character: 0}))
+}
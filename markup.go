@@ -0,0 +1,418 @@
+package spellchecker
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maskedTokenizer reads the whole input, replaces the parts which must not be checked with spaces
+// and passes the result to the inner tokenizer. Offsets are preserved because masking never changes the text length
+func maskedTokenizer(inner Tokenizer, mask func(text []byte)) Tokenizer {
+	if inner == nil {
+		inner = WordTokenizer()
+	}
+
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		text, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		mask(text)
+
+		return inner.Tokenize(bytes.NewReader(text), fn)
+	})
+}
+
+// maskRange replaces bytes with spaces keeping line breaks
+func maskRange(text []byte, start, end int) {
+	for i := start; i < end && i < len(text); i++ {
+		if text[i] != '\n' && text[i] != '\r' {
+			text[i] = ' '
+		}
+	}
+}
+
+// HTMLTokenizer skips HTML tags with their attributes, comments, entities and contents of script and style elements.
+// WordTokenizer is used for the rest of the text if inner is nil
+func HTMLTokenizer(inner Tokenizer) Tokenizer {
+	return maskedTokenizer(inner, maskHTML)
+}
+
+var htmlEntity = regexp.MustCompile(`&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+
+func maskHTML(text []byte) {
+	for i := 0; i < len(text); i++ {
+		if text[i] != '<' || i+1 >= len(text) {
+			continue
+		}
+
+		next := text[i+1]
+		switch {
+		case bytes.HasPrefix(text[i:], []byte("<!--")):
+			end := bytes.Index(text[i+4:], []byte("-->"))
+			if end < 0 {
+				end = len(text)
+			} else {
+				end += i + 4 + 3
+			}
+			maskRange(text, i, end)
+			i = end - 1
+		case isASCIILetter(next) || next == '/' || next == '!' || next == '?':
+			end := tagEnd(text, i)
+			if end < 0 {
+				continue
+			}
+			name := strings.ToLower(tagName(text[i+1 : end]))
+			maskRange(text, i, end)
+			i = end - 1
+			if name != "script" && name != "style" {
+				continue
+			}
+			// skip element contents up to the closing tag
+			closing := bytes.Index(bytes.ToLower(text[end:]), []byte("</"+name))
+			if closing < 0 {
+				closing = len(text)
+			} else {
+				closing += end
+			}
+			maskRange(text, end, closing)
+			i = closing - 1
+		}
+	}
+
+	for _, loc := range htmlEntity.FindAllIndex(text, -1) {
+		maskRange(text, loc[0], loc[1])
+	}
+}
+
+// tagEnd returns the offset after the closing '>' of the tag, respecting quoted attribute values
+func tagEnd(text []byte, start int) int {
+	var quote byte
+	for i := start + 1; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		case c == '<':
+			// not a tag, i.e. "a < b"
+			return -1
+		}
+	}
+
+	return -1
+}
+
+func tagName(tag []byte) string {
+	end := 0
+	for end < len(tag) && (isASCIILetter(tag[end]) || (end > 0 && tag[end] >= '0' && tag[end] <= '9')) {
+		end++
+	}
+
+	return string(tag[:end])
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// MarkdownTokenizer skips fenced code blocks, code spans, link targets, reference definitions and inline HTML.
+// WordTokenizer is used for the rest of the text if inner is nil
+func MarkdownTokenizer(inner Tokenizer) Tokenizer {
+	return maskedTokenizer(inner, maskMarkdown)
+}
+
+var (
+	markdownFence     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	markdownReference = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:[ \t]*\S+.*$`)
+)
+
+func maskMarkdown(text []byte) {
+	// fenced code blocks and reference definitions
+	var fence []byte
+	for offset := 0; offset < len(text); {
+		end := bytes.IndexByte(text[offset:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += offset + 1
+		}
+		line := bytes.TrimRight(text[offset:end], "\r\n")
+
+		switch {
+		case fence != nil:
+			if m := markdownFence.FindSubmatch(line); m != nil && m[1][0] == fence[0] && len(m[1]) >= len(fence) {
+				fence = nil
+			}
+			maskRange(text, offset, end)
+		case markdownFence.Match(line):
+			fence = append([]byte(nil), markdownFence.FindSubmatch(line)[1]...)
+			maskRange(text, offset, end)
+		case markdownReference.Match(line):
+			maskRange(text, offset, end)
+		}
+		offset = end
+	}
+
+	maskCodeSpans(text)
+	maskLinkTargets(text)
+	maskHTML(text)
+}
+
+// maskCodeSpans masks text between backtick strings of the same length
+func maskCodeSpans(text []byte) {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+
+		n := backticks(text, i)
+		closing := -1
+		for j := i + n; j < len(text); {
+			if text[j] != '`' {
+				j++
+				continue
+			}
+			m := backticks(text, j)
+			if m == n {
+				closing = j
+				break
+			}
+			j += m
+		}
+		if closing < 0 {
+			i += n
+			continue
+		}
+
+		maskRange(text, i, closing+n)
+		i = closing + n
+	}
+}
+
+func backticks(text []byte, start int) int {
+	n := 0
+	for start+n < len(text) && text[start+n] == '`' {
+		n++
+	}
+
+	return n
+}
+
+// maskLinkTargets masks "(target)" parts of "[text](target)" links and images
+func maskLinkTargets(text []byte) {
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] != ']' || text[i+1] != '(' {
+			continue
+		}
+
+		depth := 0
+		end := -1
+		for j := i + 1; j < len(text) && text[j] != '\n'; j++ {
+			if text[j] == '(' {
+				depth++
+			} else if text[j] == ')' {
+				depth--
+				if depth == 0 {
+					end = j + 1
+					break
+				}
+			}
+		}
+		if end < 0 {
+			continue
+		}
+
+		maskRange(text, i+1, end)
+		i = end - 1
+	}
+}
+
+// SourceSyntax describes comments and string literals of a programming language
+type SourceSyntax struct {
+	// LineComments line comment prefixes
+	LineComments []string
+	// BlockComments pairs of block comment delimiters
+	BlockComments [][2]string
+	// Strings string literal delimiters, escape sequences are processed inside them
+	Strings []string
+	// RawStrings raw string literal delimiters, escape sequences are not processed inside them
+	RawStrings []string
+	// Runes rune (character) literal delimiters, rune literals are skipped as code
+	Runes []string
+}
+
+var (
+	// GoSyntax Go comments, interpreted and raw strings. Rune literals are skipped
+	GoSyntax = SourceSyntax{
+		LineComments:  []string{"//"},
+		BlockComments: [][2]string{{"/*", "*/"}},
+		Strings:       []string{`"`},
+		RawStrings:    []string{"`"},
+		Runes:         []string{"'"},
+	}
+	// JavaScriptSyntax JavaScript/TypeScript comments, strings and template literals
+	JavaScriptSyntax = SourceSyntax{
+		LineComments:  []string{"//"},
+		BlockComments: [][2]string{{"/*", "*/"}},
+		Strings:       []string{`"`, "'", "`"},
+	}
+	// PythonSyntax Python comments, strings and docstrings
+	PythonSyntax = SourceSyntax{
+		LineComments: []string{"#"},
+		Strings:      []string{`"""`, `'''`, `"`, "'"},
+	}
+)
+
+// SourceTokenizer passes only comments and string literals of the source code to the inner tokenizer.
+// WordTokenizer is used if inner is nil
+func SourceTokenizer(syntax SourceSyntax, inner Tokenizer) Tokenizer {
+	return maskedTokenizer(inner, func(text []byte) {
+		maskSource(syntax, text)
+	})
+}
+
+func maskSource(syntax SourceSyntax, text []byte) {
+	// code is masked, comments and literals are kept
+	code := 0
+	keep := func(start, end int) {
+		maskRange(text, code, start)
+		code = end
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		if prefix := matchPrefix(rest, syntax.LineComments); prefix != "" {
+			end := bytes.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			keep(i+len(prefix), i+end)
+			i += end
+			continue
+		}
+		if delims, ok := matchBlockComment(rest, syntax.BlockComments); ok {
+			end := bytes.Index(rest[len(delims[0]):], []byte(delims[1]))
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(delims[0])
+			}
+			keep(i+len(delims[0]), i+end)
+			i += end + len(delims[1])
+			code = i
+			continue
+		}
+		if quote := matchPrefix(rest, syntax.RawStrings); quote != "" {
+			end := bytes.Index(rest[len(quote):], []byte(quote))
+			if end < 0 {
+				end = len(rest) - len(quote)
+			}
+			keep(i+len(quote), i+len(quote)+end)
+			i += len(quote) + end + len(quote)
+			code = i
+			continue
+		}
+		if quote := matchPrefix(rest, syntax.Runes); quote != "" {
+			// quotes inside rune literals, i.e. '"', must not start strings
+			i += stringEnd(rest, quote) + len(quote)
+			continue
+		}
+		if quote := matchPrefix(rest, syntax.Strings); quote != "" {
+			end := stringEnd(rest, quote)
+			keep(i+len(quote), i+end)
+			maskEscapes(text[i+len(quote) : i+end])
+			i += end + len(quote)
+			code = i
+			continue
+		}
+		i++
+	}
+	if code < len(text) {
+		maskRange(text, code, len(text))
+	}
+}
+
+func matchPrefix(text []byte, prefixes []string) string {
+	for _, p := range prefixes {
+		if bytes.HasPrefix(text, []byte(p)) {
+			return p
+		}
+	}
+
+	return ""
+}
+
+func matchBlockComment(text []byte, delims [][2]string) ([2]string, bool) {
+	for _, d := range delims {
+		if bytes.HasPrefix(text, []byte(d[0])) {
+			return d, true
+		}
+	}
+
+	return [2]string{}, false
+}
+
+// stringEnd returns the offset of the closing quote. Single-symbol quoted strings end at a line break
+func stringEnd(text []byte, quote string) int {
+	for i := len(quote); i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '\n' && len(quote) == 1 && quote != "`":
+			return i
+		case bytes.HasPrefix(text[i:], []byte(quote)):
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// maskEscapes masks escape sequences, i.e. "\n" or "\u00e9", so that they are not glued to words
+func maskEscapes(text []byte) {
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 >= len(text) {
+			continue
+		}
+
+		n := 2
+		switch text[i+1] {
+		case 'x':
+			n = 4
+		case 'u':
+			n = 6
+		case 'U':
+			n = 10
+		}
+		maskRange(text, i, i+n)
+		i += n - 1
+	}
+}
+
+// MarkupTokenizer returns a markup-aware tokenizer for the file name extension
+// (HTML, Markdown, Go, JavaScript/TypeScript or Python) or the inner tokenizer for other files
+func MarkupTokenizer(filename string, inner Tokenizer) Tokenizer {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".html", ".htm", ".xhtml", ".xml", ".svg":
+		return HTMLTokenizer(inner)
+	case ".md", ".markdown":
+		return MarkdownTokenizer(inner)
+	case ".go":
+		return SourceTokenizer(GoSyntax, inner)
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx":
+		return SourceTokenizer(JavaScriptSyntax, inner)
+	case ".py":
+		return SourceTokenizer(PythonSyntax, inner)
+	}
+
+	return inner
+}
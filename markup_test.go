@@ -0,0 +1,135 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tokenWords(t Tokenizer, text string) []string {
+	var result []string
+	for _, token := range tokenize(t, text) {
+		result = append(result, token.Word)
+	}
+	return result
+}
+
+func Test_HTMLTokenizer(t *testing.T) {
+	t.Run("must skip tags and attributes", func(t *testing.T) {
+		text := `<p class="note" title='a > b'>Green <b>tea</b></p>`
+		require.Equal(t, []string{"Green", "tea"}, tokenWords(HTMLTokenizer(nil), text))
+	})
+
+	t.Run("must skip comments, entities, scripts and styles", func(t *testing.T) {
+		text := "<!-- todo -->black&nbsp;tea<script>var x = 1;</script><STYLE>p { color: red }</STYLE> &#169; milk"
+		require.Equal(t, []string{"black", "tea", "milk"}, tokenWords(HTMLTokenizer(nil), text))
+	})
+
+	t.Run("must keep text comparisons", func(t *testing.T) {
+		require.Equal(t, []string{"a", "b"}, tokenWords(HTMLTokenizer(nil), "a < b"))
+	})
+
+	t.Run("must preserve offsets", func(t *testing.T) {
+		result := tokenize(HTMLTokenizer(nil), "<p>\n  <i>Tea</i>")
+		require.Equal(t, []Token{{Word: "Tea", Normalized: "tea", Offset: 9}}, result)
+	})
+}
+
+func Test_MarkdownTokenizer(t *testing.T) {
+	t.Run("must skip fenced code blocks", func(t *testing.T) {
+		text := "Green\n```go\nfunc main() {}\n```\ntea\n~~~~\nxyz\n~~~\nqwe\n~~~~\nmilk"
+		require.Equal(t, []string{"Green", "tea", "milk"}, tokenWords(MarkdownTokenizer(nil), text))
+	})
+
+	t.Run("must skip code spans", func(t *testing.T) {
+		text := "use `fmt.Println` and ``a ` b`` here"
+		require.Equal(t, []string{"use", "and", "here"}, tokenWords(MarkdownTokenizer(nil), text))
+	})
+
+	t.Run("must skip link targets and reference definitions", func(t *testing.T) {
+		text := "[Green tea](https://example.com/green_(tea)) ![image](img.png)\n[ref]: https://example.com/x\n<https://example.com> done"
+		require.Equal(t, []string{"Green", "tea", "image", "done"}, tokenWords(MarkdownTokenizer(nil), text))
+	})
+
+	t.Run("must skip inline HTML", func(t *testing.T) {
+		require.Equal(t, []string{"Green", "tea"}, tokenWords(MarkdownTokenizer(nil), `<span class="x">Green</span> tea`))
+	})
+
+	t.Run("must preserve offsets", func(t *testing.T) {
+		result := tokenize(MarkdownTokenizer(nil), "`x` Tea")
+		require.Equal(t, []Token{{Word: "Tea", Normalized: "tea", Offset: 4}}, result)
+	})
+}
+
+func Test_SourceTokenizer(t *testing.T) {
+	t.Run("must return Go comments and strings", func(t *testing.T) {
+		text := "// Package main greets\npackage main\n\n/* block\ncomment */\nfunc main() {\n\tfmt.Println(\"hello\\nworld\", `raw`, 'x') // done\n}"
+		require.Equal(t,
+			[]string{"Package", "main", "greets", "block", "comment", "hello", "world", "raw", "done"},
+			tokenWords(SourceTokenizer(GoSyntax, nil), text),
+		)
+	})
+
+	t.Run("must not treat comment markers inside strings as comments", func(t *testing.T) {
+		text := `url := "http://example" + identifier`
+		require.Equal(t, []string{"http", "example"}, tokenWords(SourceTokenizer(GoSyntax, nil), text))
+	})
+
+	t.Run("must skip rune literals", func(t *testing.T) {
+		text := "if c == '\"' || c == '\\'' || c == '`' { // quote\n\treturn \"tea\"\n}"
+		require.Equal(t, []string{"quote", "tea"}, tokenWords(SourceTokenizer(GoSyntax, nil), text))
+	})
+
+	t.Run("must return JavaScript comments, strings and templates", func(t *testing.T) {
+		text := "const greeting = 'hello' + \"big\" + `wide\nworld` // comment\n/** docs */"
+		require.Equal(t,
+			[]string{"hello", "big", "wide", "world", "comment", "docs"},
+			tokenWords(SourceTokenizer(JavaScriptSyntax, nil), text),
+		)
+	})
+
+	t.Run("must return Python comments, strings and docstrings", func(t *testing.T) {
+		text := "def greet(name):\n    \"\"\"Greet the\n    user\"\"\"\n    return 'hello ' + name  # it's fine"
+		require.Equal(t,
+			[]string{"Greet", "the", "user", "hello", "it's", "fine"},
+			tokenWords(SourceTokenizer(PythonSyntax, nil), text),
+		)
+	})
+
+	t.Run("must mask escape sequences", func(t *testing.T) {
+		text := `"tea\u00e9milk\x41bread\tcake"`
+		require.Equal(t, []string{"tea", "milk", "bread", "cake"}, tokenWords(SourceTokenizer(GoSyntax, nil), text))
+	})
+
+	t.Run("must preserve offsets", func(t *testing.T) {
+		result := tokenize(SourceTokenizer(GoSyntax, nil), "x := 1 // Tea")
+		require.Equal(t, []Token{{Word: "Tea", Normalized: "tea", Offset: 10}}, result)
+	})
+}
+
+func Test_MarkupTokenizer(t *testing.T) {
+	t.Run("must choose tokenizer by extension", func(t *testing.T) {
+		require.Equal(t, []string{"tea"}, tokenWords(MarkupTokenizer("index.HTML", nil), "<b>tea</b>"))
+		require.Equal(t, []string{"tea"}, tokenWords(MarkupTokenizer("README.md", nil), "`code` tea"))
+		require.Equal(t, []string{"tea"}, tokenWords(MarkupTokenizer("file:///src/main.go", nil), "x := 1 // tea"))
+		require.Equal(t, []string{"tea"}, tokenWords(MarkupTokenizer("app.ts", nil), "let x = 1 // tea"))
+		require.Equal(t, []string{"tea"}, tokenWords(MarkupTokenizer("app.py", nil), "x = 1  # tea"))
+	})
+
+	t.Run("must return inner tokenizer for other files", func(t *testing.T) {
+		require.Nil(t, MarkupTokenizer("notes.txt", nil))
+		inner := WhitespaceTokenizer()
+		require.NotNil(t, MarkupTokenizer("notes.txt", inner))
+	})
+}
+
+func Test_Spellchecker_CheckTextWith(t *testing.T) {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("green", "tea")
+
+	result := s.CheckTextWith("<p class=\"grean\">grean tea</p>", HTMLTokenizer(nil))
+	require.Equal(t, []Misspelling{{Token{Word: "grean", Normalized: "grean", Offset: 17}}}, result)
+
+	require.Equal(t, "<p class=\"grean\">green tea</p>", s.FixTextWith("<p class=\"grean\">grean tea</p>", HTMLTokenizer(nil)))
+}
@@ -0,0 +1,152 @@
+package spellchecker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WordCount is a dictionary word with its occurrence counter
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// Match returns dictionary words matching the wildcard pattern sorted by word:
+// "?" matches any symbol, "*" matches any sequence of symbols,
+// "[abc]", "[a-z]" match a symbol of the class, "[!abc]" or "[^abc]" match a symbol not in the class.
+// Special symbols are escaped with "\"
+func (s *Spellchecker) Match(pattern string) ([]WordCount, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	re, prefix, err := compileWildcard(s.normalize(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []WordCount
+	s.dict.withPrefix(prefix, func(id uint32, word string) bool {
+		if re.MatchString(word) {
+			result = append(result, s.wordCount(id))
+		}
+		return true
+	})
+
+	return result, nil
+}
+
+// MatchRegexp returns dictionary words matching the regular expression sorted by word.
+// Words are matched in their normalized form, anchor the expression to match whole words
+func (s *Spellchecker) MatchRegexp(re *regexp.Regexp) []WordCount {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var result []WordCount
+	for _, id := range s.dict.sortedIDs() {
+		if re.MatchString(s.dict.words[id]) {
+			result = append(result, s.wordCount(id))
+		}
+	}
+
+	return result
+}
+
+// wordCount returns the word in its cased or original form with the counter
+func (s *Spellchecker) wordCount(id uint32) WordCount {
+	word, ok := s.dict.forms[id]
+	if !ok {
+		word = s.dict.original(id)
+	}
+
+	return WordCount{Word: word, Count: s.dict.counts[id]}
+}
+
+// compileWildcard converts the wildcard pattern to an anchored regular expression.
+// Also returns the literal prefix of the pattern to narrow the search
+func compileWildcard(pattern string) (*regexp.Regexp, string, error) {
+	var sb, prefix strings.Builder
+	sb.WriteString("^")
+	literal := true
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+			literal = false
+		case '?':
+			sb.WriteString(".")
+			literal = false
+		case '[':
+			end, class, err := wildcardClass(runes, i)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			sb.WriteString(class)
+			literal = false
+			i = end
+		default:
+			if r == '\\' {
+				if i == len(runes)-1 {
+					return nil, "", fmt.Errorf("invalid pattern %q: trailing escape", pattern)
+				}
+				i++
+				r = runes[i]
+			}
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			if literal {
+				prefix.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	return re, prefix.String(), nil
+}
+
+// wildcardClass converts the character class starting at runes[start] to a regular expression class.
+// Returns the position of the closing bracket
+func wildcardClass(runes []rune, start int) (int, string, error) {
+	var sb strings.Builder
+	sb.WriteString("[")
+
+	i := start + 1
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		sb.WriteString("^")
+		i++
+	}
+
+	first := i
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ']' && i > first:
+			sb.WriteString("]")
+			return i, sb.String(), nil
+		case r == '-' && i > first && i+1 < len(runes) && runes[i+1] != ']':
+			sb.WriteString("-")
+		case r == '\\' && i+1 < len(runes):
+			i++
+			sb.WriteString(quoteClassRune(runes[i]))
+		default:
+			sb.WriteString(quoteClassRune(r))
+		}
+	}
+
+	return 0, "", fmt.Errorf("unclosed character class")
+}
+
+func quoteClassRune(r rune) string {
+	if r == '-' || r == '^' {
+		return `\` + string(r)
+	}
+
+	return regexp.QuoteMeta(string(r))
+}
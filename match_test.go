@@ -0,0 +1,86 @@
+package spellchecker
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_Match(t *testing.T) {
+	s, err := New(DefaultAlphabet + "-*")
+	require.NoError(t, err)
+	s.Add("cat", "cat", "cot", "cut", "coat", "dog", "co-op", "c*t")
+	s.AddCased("Catalina")
+
+	cases := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"c?t", []string{"c*t", "cat", "cot", "cut"}},
+		{"c*t", []string{"c*t", "cat", "coat", "cot", "cut"}},
+		{`c\*t`, []string{"c*t"}},
+		{"c[ao]t", []string{"cat", "cot"}},
+		{"c[a-o]t", []string{"cat", "cot"}},
+		{"c[!ao]t", []string{"c*t", "cut"}},
+		{"c[^a-z]t", []string{"c*t"}},
+		{"co[-]op", []string{"co-op"}},
+		{"Cat*", []string{"cat", "Catalina"}},
+		{"*g", []string{"dog"}},
+		{"x*", nil},
+	}
+	for _, c := range cases {
+		t.Run("must match "+c.pattern, func(t *testing.T) {
+			result, err := s.Match(c.pattern)
+			require.NoError(t, err)
+
+			var words []string
+			for _, wc := range result {
+				words = append(words, wc.Word)
+			}
+			require.Equal(t, c.expected, words)
+		})
+	}
+
+	t.Run("must return counters", func(t *testing.T) {
+		result, err := s.Match("ca?")
+		require.NoError(t, err)
+		require.Equal(t, []WordCount{{Word: "cat", Count: 2}}, result)
+	})
+
+	t.Run("must return error on invalid pattern", func(t *testing.T) {
+		_, err := s.Match("c[at")
+		require.Error(t, err)
+		_, err = s.Match(`cat\`)
+		require.Error(t, err)
+	})
+}
+
+func Test_Spellchecker_MatchRegexp(t *testing.T) {
+	s := newTestSpellchecker(t, "cat", "cot", "cut", "coat", "dog")
+	s.AddCased("Catalina")
+
+	require.Equal(t, []WordCount{{Word: "coat", Count: 1}, {Word: "cot", Count: 1}}, s.MatchRegexp(regexp.MustCompile(`^co.?t$`)))
+	require.Equal(t, []WordCount{{Word: "Catalina", Count: 1}}, s.MatchRegexp(regexp.MustCompile(`ali`)))
+}
+
+func Test_compileWildcard(t *testing.T) {
+	cases := []struct {
+		pattern string
+		re      string
+		prefix  string
+	}{
+		{"abc", "^abc$", "abc"},
+		{"ab?d*", "^ab.d.*$", "ab"},
+		{`a\?b`, `^a\?b$`, "a?b"},
+		{"[]a]", `^[\]a]$`, ""},
+		{"[!a-c]x", `^[^a-c]x$`, ""},
+		{"a.b", `^a\.b$`, "a.b"},
+	}
+	for _, c := range cases {
+		re, prefix, err := compileWildcard(c.pattern)
+		require.NoError(t, err)
+		require.Equal(t, c.re, re.String(), c.pattern)
+		require.Equal(t, c.prefix, prefix, c.pattern)
+	}
+}
@@ -0,0 +1,176 @@
+package spellchecker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+var ErrAlphabetMismatch = fmt.Errorf("alphabets do not match")
+
+// MergeStrategy computes a resulting word counter from the counters of two dictionaries.
+// The counter of a word missing in one of the dictionaries is 0.
+// Words with resulting counter less than 1 are removed
+type MergeStrategy func(a, b int) int
+
+// MergeSum sums counters of both dictionaries
+var MergeSum MergeStrategy = func(a, b int) int {
+	return a + b
+}
+
+// MergeMax takes the max counter of both dictionaries
+var MergeMax MergeStrategy = func(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// MergeWeighted sums counters of both dictionaries multiplied by the provided weights
+func MergeWeighted(wa, wb float64) MergeStrategy {
+	return func(a, b int) int {
+		return int(math.Round(float64(a)*wa + float64(b)*wb))
+	}
+}
+
+// Merge adds words from the other spellchecker to the dictionary, computing counters with the provided strategy.
+// Both spellcheckers must have the same alphabet
+func (s *Spellchecker) Merge(other *Spellchecker, strategy MergeStrategy) error {
+	if s == other {
+		return fmt.Errorf("unable to merge spellchecker with itself")
+	}
+	if strategy == nil {
+		return fmt.Errorf("merge strategy is not provided")
+	}
+
+	// the other spellchecker is copied before locking this one, so concurrent a.Merge(b) and b.Merge(a) don't deadlock
+	src := other.snapshot()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.dict.alphabet.compare(src.alphabet); err != nil {
+		return err
+	}
+
+	// counters are keyed by original forms of words, they are normalized by each spellchecker
+	counts := make(map[string]int, len(s.dict.ids)+len(src.counts))
+	for id := range s.dict.words {
+		word := s.dict.original(id)
+		if _, ok := src.counts[src.normalization.apply(word)]; ok {
+			continue
+		}
+		if cnt := strategy(s.dict.counts[id], 0); cnt != s.dict.counts[id] {
+			counts[word] = cnt
+		}
+	}
+	for key, otherCnt := range src.counts {
+		word := src.originals[key]
+		var cnt int
+		if ownID := s.dict.id(s.normalize(word)); ownID > 0 {
+			cnt = s.dict.counts[ownID]
+		}
+		counts[word] = strategy(cnt, otherCnt)
+	}
+
+	for word, cnt := range counts {
+		s.set(word, cnt)
+	}
+	s.journal.writeCounts(counts)
+
+	s.setForms(src.forms...)
+	s.journal.write(opForm, src.forms...)
+
+	return nil
+}
+
+// dictSnapshot is a copy of dictionary words used to combine two spellcheckers without holding both locks
+type dictSnapshot struct {
+	alphabet      alphabet
+	normalization Normalization
+	// counts counters by normalized words
+	counts map[string]int
+	// originals original forms by normalized words
+	originals map[string]string
+	forms     []string
+}
+
+func (s *Spellchecker) snapshot() dictSnapshot {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	result := dictSnapshot{
+		// the alphabet is never changed after creation
+		alphabet:      s.dict.alphabet,
+		normalization: s.normalization,
+		counts:        make(map[string]int, len(s.dict.words)),
+		originals:     make(map[string]string, len(s.dict.words)),
+		forms:         make([]string, 0, len(s.dict.forms)),
+	}
+	for id, word := range s.dict.words {
+		result.counts[word] = s.dict.counts[id]
+		result.originals[word] = s.dict.original(id)
+	}
+	for _, form := range s.dict.forms {
+		result.forms = append(result.forms, form)
+	}
+
+	return result
+}
+
+// CountChange describes a word which counter differs in two dictionaries
+type CountChange struct {
+	Word string
+	Old  int
+	New  int
+}
+
+// DictionaryDiff describes a difference between two dictionaries
+type DictionaryDiff struct {
+	// Added words present in the second dictionary only
+	Added []string
+	// Removed words present in the first dictionary only
+	Removed []string
+	// Changed words present in both dictionaries with different counters
+	Changed []CountChange
+}
+
+// Diff compares dictionaries of two spellcheckers. Results are sorted by word
+func Diff(a, b *Spellchecker) DictionaryDiff {
+	result := DictionaryDiff{}
+	if a == b {
+		return result
+	}
+
+	// b is copied before locking a, so concurrent Diff() and Merge() calls don't deadlock
+	src := b.snapshot()
+
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	for id, word := range a.dict.words {
+		cnt, ok := src.counts[word]
+		if !ok {
+			result.Removed = append(result.Removed, word)
+			continue
+		}
+		if a.dict.counts[id] != cnt {
+			result.Changed = append(result.Changed, CountChange{
+				Word: word,
+				Old:  a.dict.counts[id],
+				New:  cnt,
+			})
+		}
+	}
+	for word := range src.counts {
+		if !a.dict.has(word) {
+			result.Added = append(result.Added, word)
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Slice(result.Changed, func(i, j int) bool { return result.Changed[i].Word < result.Changed[j].Word })
+
+	return result
+}
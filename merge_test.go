@@ -0,0 +1,106 @@
+package spellchecker
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mergeWordsA = []string{"orange", "orange", "apple", "tea"}
+	mergeWordsB = []string{"orange", "apple", "apple", "apple", "range"}
+)
+
+func Test_Spellchecker_Merge(t *testing.T) {
+	count := func(s *Spellchecker, word string) int {
+		return s.dict.counts[s.dict.id(word)]
+	}
+
+	t.Run("must sum counters", func(t *testing.T) {
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		require.NoError(t, a.Merge(b, MergeSum))
+		require.Equal(t, 3, count(a, "orange"))
+		require.Equal(t, 4, count(a, "apple"))
+		require.Equal(t, 1, count(a, "tea"))
+		require.Equal(t, 1, count(a, "range"))
+
+		result, err := a.Suggest("arang", 5)
+		require.NoError(t, err)
+		require.Equal(t, []string{"orange", "range"}, result)
+	})
+
+	t.Run("must take max counters", func(t *testing.T) {
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		require.NoError(t, a.Merge(b, MergeMax))
+		require.Equal(t, 2, count(a, "orange"))
+		require.Equal(t, 3, count(a, "apple"))
+	})
+
+	t.Run("must weight counters and drop zero counters", func(t *testing.T) {
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		require.NoError(t, a.Merge(b, MergeWeighted(2, 0.4)))
+		require.Equal(t, 4, count(a, "orange"))
+		require.Equal(t, 3, count(a, "apple"))
+		require.Equal(t, 2, count(a, "tea"))
+		require.False(t, a.IsCorrect("range"))
+	})
+
+	t.Run("must fail if alphabets do not match", func(t *testing.T) {
+		a := newTestSpellchecker(t, mergeWordsA...)
+		b, err := New("abc")
+		require.NoError(t, err)
+		require.ErrorIs(t, a.Merge(b, MergeSum), ErrAlphabetMismatch)
+	})
+
+	t.Run("must fail without strategy", func(t *testing.T) {
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		require.Error(t, a.Merge(b, nil))
+	})
+
+	t.Run("must not deadlock on concurrent merges", func(t *testing.T) {
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, a.Merge(b, MergeMax))
+			}()
+			go func() {
+				defer wg.Done()
+				require.NoError(t, b.Merge(a, MergeMax))
+			}()
+			go func() {
+				defer wg.Done()
+				Diff(b, a)
+			}()
+		}
+		wg.Wait()
+		require.Empty(t, Diff(a, b))
+	})
+
+	t.Run("must write merged counters to the journal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+		require.NoError(t, a.WithOpts(WithJournal(buf)))
+		require.NoError(t, a.Merge(b, MergeSum))
+
+		c := newTestSpellchecker(t, mergeWordsA...)
+		require.NoError(t, c.Replay(buf))
+		require.Empty(t, Diff(a, c))
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	a, b := newTestSpellchecker(t, mergeWordsA...), newTestSpellchecker(t, mergeWordsB...)
+
+	result := Diff(a, b)
+	require.Equal(t, []string{"range"}, result.Added)
+	require.Equal(t, []string{"tea"}, result.Removed)
+	require.Equal(t, []CountChange{
+		{Word: "apple", Old: 1, New: 3},
+		{Word: "orange", Old: 2, New: 1},
+	}, result.Changed)
+}
@@ -0,0 +1,158 @@
+package spellchecker
+
+import (
+	"fmt"
+	"sync"
+	"unicode"
+)
+
+var ErrUnknownLanguage = fmt.Errorf("unknown language")
+
+// MultiSpellchecker holds a spellchecker per language and routes queries to the language detected
+// by alphabet coverage and dictionary hits
+type MultiSpellchecker struct {
+	mtx sync.RWMutex
+
+	languages map[string]*Spellchecker
+	// order languages in order of addition, the first one wins ties
+	order []string
+}
+
+// NewMulti create an empty multi-language spellchecker
+func NewMulti() *MultiSpellchecker {
+	return &MultiSpellchecker{
+		languages: make(map[string]*Spellchecker),
+	}
+}
+
+// AddLanguage adds or replaces the spellchecker of the language
+func (m *MultiSpellchecker) AddLanguage(lang string, s *Spellchecker) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.languages[lang]; !ok {
+		m.order = append(m.order, lang)
+	}
+	m.languages[lang] = s
+}
+
+// Languages returns languages in order of addition
+func (m *MultiSpellchecker) Languages() []string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	result := make([]string, len(m.order))
+	copy(result, m.order)
+
+	return result
+}
+
+// Language returns the spellchecker of the language, use it to override language detection
+func (m *MultiSpellchecker) Language(lang string) (*Spellchecker, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	s, ok := m.languages[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownLanguage, lang)
+	}
+
+	return s, nil
+}
+
+// Detect returns the most likely language of a word or a text, an empty string if no language matches.
+// Every word scores its alphabet coverage in each language plus a bonus if the word is present in the dictionary
+func (m *MultiSpellchecker) Detect(text string) string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.detect(tokenize(defaultTokenizer, text))
+}
+
+func (m *MultiSpellchecker) detect(tokens []Token) string {
+	best, bestScore := "", 0.0
+	for _, lang := range m.order {
+		s := m.languages[lang]
+		score := 0.0
+		for _, t := range tokens {
+			coverage := s.coverage(t.Word)
+			if coverage == 0 {
+				continue
+			}
+			score += coverage
+			if s.IsCorrect(t.Word) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+
+	return best
+}
+
+// IsCorrect check if the word is correct in its detected language
+func (m *MultiSpellchecker) IsCorrect(word string) bool {
+	s := m.route(word)
+	if s == nil {
+		return false
+	}
+
+	return s.IsCorrect(word)
+}
+
+// Fix find the best suggestion in the detected language of the word
+func (m *MultiSpellchecker) Fix(word string) (string, error) {
+	s := m.route(word)
+	if s == nil {
+		return word, ErrUnknownWord
+	}
+
+	return s.Fix(word)
+}
+
+// Suggest find top n suggestions in the detected language of the word
+func (m *MultiSpellchecker) Suggest(word string, n int) ([]string, error) {
+	s := m.route(word)
+	if s == nil {
+		return []string{word}, ErrUnknownWord
+	}
+
+	return s.Suggest(word, n)
+}
+
+// route returns the spellchecker of the detected language of the word or nil
+func (m *MultiSpellchecker) route(word string) *Spellchecker {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	lang := m.detect([]Token{{Word: word}})
+	if lang == "" {
+		return nil
+	}
+
+	return m.languages[lang]
+}
+
+// coverage returns a share of the word letters present in the alphabet
+func (s *Spellchecker) coverage(word string) float64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	total, covered := 0, 0
+	for _, r := range s.normalize(word) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		total++
+		if _, ok := s.dict.alphabet[r]; ok {
+			covered++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	return float64(covered) / float64(total)
+}
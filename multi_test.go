@@ -0,0 +1,86 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiSpellchecker(t *testing.T) *MultiSpellchecker {
+	en := newTestSpellchecker(t, "hello", "world", "green", "tea", "moon")
+
+	ru, err := New(cyrillicAlphabet)
+	require.NoError(t, err)
+	ru.Add("привет", "мир", "зелёный", "чай")
+
+	de, err := New(DefaultAlphabet + "äöüß")
+	require.NoError(t, err)
+	de.Add("hallo", "welt", "grün", "mond")
+
+	m := NewMulti()
+	m.AddLanguage("en", en)
+	m.AddLanguage("ru", ru)
+	m.AddLanguage("de", de)
+
+	return m
+}
+
+func Test_MultiSpellchecker_Detect(t *testing.T) {
+	m := newMultiSpellchecker(t)
+
+	t.Run("must detect language by alphabet", func(t *testing.T) {
+		require.Equal(t, "ru", m.Detect("превет"))
+		require.Equal(t, "de", m.Detect("grüne"))
+	})
+
+	t.Run("must detect language by dictionary hits", func(t *testing.T) {
+		require.Equal(t, "de", m.Detect("hallo welt"))
+		require.Equal(t, "en", m.Detect("hello world"))
+	})
+
+	t.Run("must prefer the first language on ties", func(t *testing.T) {
+		require.Equal(t, "en", m.Detect("xyz"))
+	})
+
+	t.Run("must return empty string for unknown scripts", func(t *testing.T) {
+		require.Equal(t, "", m.Detect("αθήνα"))
+		require.Equal(t, "", m.Detect("123"))
+	})
+}
+
+func Test_MultiSpellchecker_Route(t *testing.T) {
+	m := newMultiSpellchecker(t)
+
+	require.True(t, m.IsCorrect("мир"))
+	require.True(t, m.IsCorrect("welt"))
+	require.False(t, m.IsCorrect("αθήνα"))
+
+	result, err := m.Fix("превет")
+	require.NoError(t, err)
+	require.Equal(t, "привет", result)
+
+	result, err = m.Fix("grün")
+	require.NoError(t, err)
+	require.Equal(t, "grün", result)
+
+	suggestions, err := m.Suggest("helo", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello"}, suggestions)
+
+	_, err = m.Fix("αθήνα")
+	require.ErrorIs(t, err, ErrUnknownWord)
+}
+
+func Test_MultiSpellchecker_Language(t *testing.T) {
+	m := newMultiSpellchecker(t)
+	require.Equal(t, []string{"en", "ru", "de"}, m.Languages())
+
+	de, err := m.Language("de")
+	require.NoError(t, err)
+	result, err := de.Fix("mund")
+	require.NoError(t, err)
+	require.Equal(t, "mond", result)
+
+	_, err = m.Language("fr")
+	require.ErrorIs(t, err, ErrUnknownLanguage)
+}
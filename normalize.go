@@ -0,0 +1,79 @@
+package spellchecker
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization describes how words are normalized before they are stored in the dictionary or looked up.
+// The zero value applies NFC and lowercases words.
+// Normalized words are used as dictionary keys, while suggestions keep the original form of dictionary words
+type Normalization struct {
+	// Form is a Unicode normalization form, norm.NFC by default. Use norm.NFKC to fold compatibility symbols (i.e. "ﬁ" => "fi")
+	Form norm.Form
+	// FoldAccents removes diacritical marks: "café" => "cafe"
+	FoldAccents bool
+	// ExpandLigatures replaces ligatures with letters: "æ" => "ae", "ß" => "ss"
+	ExpandLigatures bool
+	// FoldLetters replaces lowercase letters with their equivalents (i.e. FoldYo)
+	FoldLetters map[rune]rune
+}
+
+// FoldYo folds Russian "ё" to "е"
+var FoldYo = map[rune]rune{'ё': 'е'}
+
+var ligatures = map[rune]string{
+	'æ': "ae", 'œ': "oe", 'ß': "ss", 'ĳ': "ij",
+	'ﬀ': "ff", 'ﬁ': "fi", 'ﬂ': "fl", 'ﬃ': "ffi", 'ﬄ': "ffl", 'ﬅ': "st", 'ﬆ': "st",
+}
+
+// WithNormalization set the normalization pipeline. It must be set before adding words, it is persisted by Save()
+func WithNormalization(n Normalization) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.normalization = n
+		return nil
+	}
+}
+
+// normalize converts the word to the dictionary key
+func (s *Spellchecker) normalize(word string) string {
+	return s.normalization.apply(word)
+}
+
+func (n Normalization) apply(word string) string {
+	word = strings.ToLower(n.Form.String(word))
+	if !n.FoldAccents && !n.ExpandLigatures && len(n.FoldLetters) == 0 {
+		return word
+	}
+
+	if n.FoldAccents {
+		word = norm.NFD.String(word)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(word))
+	for _, r := range word {
+		if n.FoldAccents && unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if n.ExpandLigatures {
+			if l, ok := ligatures[r]; ok {
+				sb.WriteString(l)
+				continue
+			}
+		}
+		if f, ok := n.FoldLetters[r]; ok {
+			r = f
+		}
+		sb.WriteRune(r)
+	}
+
+	return n.Form.String(sb.String())
+}
+
+// original returns the word in the original form: composed and lowercased, but not folded
+func (n Normalization) original(word string) string {
+	return strings.ToLower(n.Form.String(word))
+}
@@ -0,0 +1,99 @@
+package spellchecker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+func Test_Normalization_apply(t *testing.T) {
+	t.Run("must compose and lowercase by default", func(t *testing.T) {
+		require.Equal(t, "café", Normalization{}.apply("Café"))
+		require.Equal(t, "ﬁnd", Normalization{}.apply("ﬁnd"))
+	})
+
+	t.Run("must apply compatibility form", func(t *testing.T) {
+		require.Equal(t, "find", Normalization{Form: norm.NFKC}.apply("ﬁnd"))
+	})
+
+	t.Run("must fold accents", func(t *testing.T) {
+		require.Equal(t, "resume", Normalization{FoldAccents: true}.apply("Résumé"))
+		require.Equal(t, "resume", Normalization{FoldAccents: true}.apply("Résumé"))
+	})
+
+	t.Run("must expand ligatures", func(t *testing.T) {
+		require.Equal(t, "aether strasse", Normalization{ExpandLigatures: true}.apply("Æther straße"))
+	})
+
+	t.Run("must fold letters", func(t *testing.T) {
+		require.Equal(t, "елка", Normalization{FoldLetters: FoldYo}.apply("Ёлка"))
+		require.Equal(t, "йод", Normalization{FoldLetters: FoldYo}.apply("йод"))
+	})
+}
+
+func Test_Spellchecker_Normalization(t *testing.T) {
+	t.Run("must match words in different normalization forms", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		s.Add("café")
+
+		require.True(t, s.IsCorrect("café"))
+		require.True(t, s.IsCorrect("café"))
+	})
+
+	t.Run("must return original forms of accent-folded words", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithNormalization(Normalization{FoldAccents: true}))
+		require.NoError(t, err)
+		s.Add("café", "résumé")
+
+		require.True(t, s.IsCorrect("cafe"))
+		for input, expected := range map[string]string{"cafe": "café", "Cafe": "Café", "resume": "résumé", "resme": "résumé"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+		// correct words are left as is
+		require.Equal(t, "Cafe résumé", s.FixText("Cafe resme"))
+	})
+
+	t.Run("must fold letters", func(t *testing.T) {
+		s, err := New("абвгдеёжзийклмнопрстуфхцчшщъыьэюя", WithNormalization(Normalization{FoldLetters: FoldYo}))
+		require.NoError(t, err)
+		s.Add("ёлка")
+
+		require.True(t, s.IsCorrect("елка"))
+		result, err := s.Fix("Елка")
+		require.NoError(t, err)
+		require.Equal(t, "Ёлка", result)
+	})
+
+	t.Run("must persist normalization", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithNormalization(Normalization{FoldAccents: true}))
+		require.NoError(t, err)
+		s.Add("café")
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.Save(buf))
+		loaded, err := Load(buf)
+		require.NoError(t, err)
+
+		result, err := loaded.Fix("cafe")
+		require.NoError(t, err)
+		require.Equal(t, "café", result)
+	})
+
+	t.Run("must merge dictionaries with different normalization", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithNormalization(Normalization{FoldAccents: true}))
+		require.NoError(t, err)
+		other, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		other.Add("café")
+
+		require.NoError(t, s.Merge(other, MergeSum))
+		result, err := s.Fix("cafe")
+		require.NoError(t, err)
+		require.Equal(t, "café", result)
+	})
+}
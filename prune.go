@@ -0,0 +1,109 @@
+package spellchecker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WithMinCount set the minimum occurrence counter for a word to be correct.
+// Less frequent words are still used as suggestions. The value is saved along with the dictionary
+func WithMinCount(minCount int) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.minCount = minCount
+		return nil
+	}
+}
+
+// WithMaxVocabulary keep only n most frequent words, 0 means no limit. The value is saved along with the dictionary.
+// The limit is applied by AddFrom() and Prune() only: Add(), Merge() and journal replay may grow the dictionary
+// beyond it until the next AddFrom() or Prune() call. Call Prune(0) to apply the limit to an existing dictionary
+func WithMaxVocabulary(n int) OptionFunc {
+	return func(s *Spellchecker) error {
+		if n < 0 {
+			return fmt.Errorf("invalid max vocabulary size %d", n)
+		}
+		s.maxVocabulary = n
+		return nil
+	}
+}
+
+// Prune removes words occurred less than minCount times, the vocabulary limit is enforced afterwards.
+// Returns the number of removed words
+func (s *Spellchecker) Prune(minCount int) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var removed []string
+	for id, cnt := range s.dict.counts {
+		if cnt < minCount {
+			removed = append(removed, s.dict.words[id])
+		}
+	}
+	sort.Strings(removed)
+	s.remove(removed...)
+	removed = append(removed, s.limitVocabulary()...)
+	s.journal.write(opRemove, removed...)
+
+	return len(removed)
+}
+
+// enforceVocabulary removes the least frequent words exceeding the vocabulary limit
+func (s *Spellchecker) enforceVocabulary() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.journal.write(opRemove, s.limitVocabulary()...)
+}
+
+// limitVocabulary removes the least frequent words exceeding the vocabulary limit and returns them.
+// Words with equal counters are kept in lexicographical order
+func (s *Spellchecker) limitVocabulary() []string {
+	if s.maxVocabulary == 0 || len(s.dict.words) <= s.maxVocabulary {
+		return nil
+	}
+
+	words := make([]string, 0, len(s.dict.words))
+	for _, word := range s.dict.words {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		ci, cj := s.dict.counts[s.dict.id(words[i])], s.dict.counts[s.dict.id(words[j])]
+		if ci != cj {
+			return ci > cj
+		}
+		return words[i] < words[j]
+	})
+
+	removed := words[s.maxVocabulary:]
+	s.remove(removed...)
+
+	return removed
+}
+
+// known check if the normalized word is frequent enough to be correct
+func (s *Spellchecker) known(normalized string) bool {
+	id := s.dict.id(normalized)
+
+	return id > 0 && s.dict.counts[id] >= s.minCount
+}
+
+// findReplacements searches candidates to replace the normalized word.
+// A word too rare to be correct is not suggested as a replacement for itself
+func (s *Spellchecker) findReplacements(normalized string, n int) []match {
+	if !s.dict.has(normalized) {
+		return s.find(normalized, n)
+	}
+
+	hits := s.find(normalized, n+1)
+	for i := range hits {
+		if hits[i].Value == normalized {
+			hits = append(hits[:i], hits[i+1:]...)
+			break
+		}
+	}
+	if len(hits) > n {
+		hits = hits[:n]
+	}
+
+	return hits
+}
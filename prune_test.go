@@ -0,0 +1,127 @@
+package spellchecker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_Prune(t *testing.T) {
+	t.Run("must remove rare words", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		s.Add("the", "the", "the", "tea", "tea", "teh", "green")
+
+		require.Equal(t, 2, s.Prune(2))
+		require.True(t, s.IsCorrect("the"))
+		require.True(t, s.IsCorrect("tea"))
+		require.False(t, s.IsCorrect("teh"))
+		require.False(t, s.IsCorrect("green"))
+	})
+
+	t.Run("must journal removed words", func(t *testing.T) {
+		journal := &bytes.Buffer{}
+		s, err := New(DefaultAlphabet, WithJournal(journal))
+		require.NoError(t, err)
+		s.Add("the", "the", "teh")
+		s.Prune(2)
+
+		replayed, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		require.NoError(t, replayed.Replay(journal))
+		require.Equal(t, 1, replayed.Len())
+		require.False(t, replayed.IsCorrect("teh"))
+	})
+}
+
+func Test_WithMaxVocabulary(t *testing.T) {
+	t.Run("must keep the most frequent words after AddFrom", func(t *testing.T) {
+		s, err := New(DefaultAlphabet, WithMaxVocabulary(2))
+		require.NoError(t, err)
+		require.NoError(t, s.AddFrom(strings.NewReader("the tea the green tea the teh black")))
+
+		require.Equal(t, 2, s.Len())
+		require.True(t, s.IsCorrect("the"))
+		require.True(t, s.IsCorrect("tea"))
+	})
+
+	t.Run("must keep words with equal counters in lexicographical order", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		s.Add("tea", "green", "black", "black")
+		require.NoError(t, s.WithOpts(WithMaxVocabulary(2)))
+		// the option does not change the dictionary
+		require.Equal(t, 3, s.Len())
+		require.Equal(t, 1, s.Prune(0))
+
+		var words []string
+		s.Words(func(word string, _ int) bool {
+			words = append(words, word)
+			return true
+		})
+		require.Equal(t, []string{"black", "green"}, words)
+	})
+
+	t.Run("must enforce the limit after Prune", func(t *testing.T) {
+		s, err := New(DefaultAlphabet)
+		require.NoError(t, err)
+		s.Add("the", "the", "tea", "tea", "green", "green", "teh")
+		require.NoError(t, s.WithOpts(WithMaxVocabulary(3)))
+		require.Equal(t, 1, s.Prune(0))
+		require.Equal(t, 3, s.Len())
+
+		// Add does not apply the limit
+		s.Add("teh", "black")
+		require.Equal(t, 5, s.Len())
+		require.Equal(t, 2, s.Prune(1))
+		require.Equal(t, 3, s.Len())
+		require.False(t, s.IsCorrect("black"))
+	})
+
+	t.Run("must return error on negative size", func(t *testing.T) {
+		_, err := New(DefaultAlphabet, WithMaxVocabulary(-1))
+		require.Error(t, err)
+	})
+}
+
+func Test_Spellchecker_Save_Limits(t *testing.T) {
+	s, err := New(DefaultAlphabet, WithMinCount(2), WithMaxVocabulary(2))
+	require.NoError(t, err)
+	s.Add("the", "the", "teh")
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, s.Save(buf))
+	loaded, err := Load(buf)
+	require.NoError(t, err)
+
+	require.True(t, loaded.IsCorrect("the"))
+	require.False(t, loaded.IsCorrect("teh"))
+	require.NoError(t, loaded.AddFrom(strings.NewReader("tea tea green")))
+	require.Equal(t, 2, loaded.Len())
+}
+
+func Test_WithMinCount(t *testing.T) {
+	s, err := New(DefaultAlphabet, WithMinCount(2))
+	require.NoError(t, err)
+	s.Add("the", "the", "the", "teh", "tea", "tea", "green")
+
+	t.Run("must treat rare words as incorrect", func(t *testing.T) {
+		require.True(t, s.IsCorrect("the"))
+		require.False(t, s.IsCorrect("teh"))
+		require.Equal(t, []Misspelling{{Token{Word: "green", Normalized: "green", Offset: 4}}}, s.CheckText("the green tea"))
+	})
+
+	t.Run("must fix rare words with frequent ones", func(t *testing.T) {
+		result, err := s.Fix("teh")
+		require.NoError(t, err)
+		require.Equal(t, "the", result)
+	})
+
+	t.Run("must keep rare words as suggestions", func(t *testing.T) {
+		result, err := s.Fix("gren")
+		require.NoError(t, err)
+		require.Equal(t, "green", result)
+	})
+}
@@ -9,7 +9,12 @@ import (
 
 type readData struct {
 	word string
-	err  error
+	// original is the word as it appears in the input, empty if the splitter changed the word
+	original string
+	// offset is a byte offset of the original word in the input.
+	// If the splitter changed the word, it is the offset of the input part the word was read from
+	offset int
+	err    error
 }
 
 var wordSymbols = regexp.MustCompile(`[-\pL]+`)
@@ -24,14 +29,57 @@ func defaultSplitter(data []byte, atEOF bool) (advance int, token []byte, err er
 	return advance, wordSymbols.Find(token), nil
 }
 
-func readInput(input io.Reader, splitter bufio.SplitFunc) <-chan readData {
-	if splitter == nil {
-		splitter = defaultSplitter
+// scanWord works like defaultSplitter, but returns the location of the word in data instead of the lowercased word.
+// start is -1 if there is no word in the advanced part of data
+func scanWord(data []byte, atEOF bool) (advance, start, end int, err error) {
+	advance, token, err := bufio.ScanWords(data, atEOF)
+	if err != nil || token == nil {
+		return advance, -1, -1, err
+	}
+
+	loc := wordSymbols.FindIndex(token)
+	if loc == nil {
+		return advance, -1, -1, nil
 	}
+	// the token is data without leading spaces, so its first occurrence is the token itself
+	tokenStart := bytes.Index(data[:advance], token)
 
+	return advance, tokenStart + loc[0], tokenStart + loc[1], nil
+}
+
+func readInput(input io.Reader, splitter bufio.SplitFunc) <-chan readData {
 	ch := make(chan readData)
 	scanner := bufio.NewScanner(input)
-	scanner.Split(splitter)
+
+	var consumed, offset int
+	var original string
+	if splitter == nil {
+		// the default splitter knows exact locations of words
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, start, end, err := scanWord(data, atEOF)
+			if start >= 0 {
+				offset, original = consumed+start, string(data[start:end])
+			}
+			consumed += advance
+			if start < 0 {
+				return advance, nil, err
+			}
+			return advance, bytes.ToLower(data[start:end]), nil
+		})
+	} else {
+		// custom splitters return tokens only, the offset is found by the token if it is not changed
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, token, err := splitter(data, atEOF)
+			if token != nil {
+				offset, original = consumed, ""
+				if i := bytes.Index(data[:advance], token); i >= 0 {
+					offset, original = consumed+i, string(token)
+				}
+			}
+			consumed += advance
+			return advance, token, err
+		})
+	}
 
 	go func() {
 		defer close(ch)
@@ -43,7 +91,7 @@ func readInput(input io.Reader, splitter bufio.SplitFunc) <-chan readData {
 				ch <- readData{err: err}
 				return
 			}
-			ch <- readData{word: scanner.Text()}
+			ch <- readData{word: scanner.Text(), original: original, offset: offset}
 		}
 	}()
 
@@ -6,7 +6,17 @@ import (
 )
 
 type spellcheckerData struct {
-	Dict *dictionary
+	Dict       *dictionary
+	Learned    learned
+	ErrorModel *ErrorModel
+
+	Normalization Normalization
+	MinCount      int
+	MaxVocabulary int
+
+	Transliterations []Transliteration
+
+	Generation uint64
 }
 
 // Save encodes spellchecker data and writes it to the provided writer
@@ -14,14 +24,29 @@ func (m *Spellchecker) Save(w io.Writer) error {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
 
+	return m.save(w)
+}
+
+func (m *Spellchecker) save(w io.Writer) error {
 	data := spellcheckerData{
-		Dict: m.dict,
+		Dict:       m.dict,
+		Learned:    m.learned,
+		ErrorModel: m.errorModel,
+
+		Normalization: m.normalization,
+		MinCount:      m.minCount,
+		MaxVocabulary: m.maxVocabulary,
+
+		Transliterations: m.transliterations(),
+
+		Generation: m.generation,
 	}
 
 	return gob.NewEncoder(w).Encode(data)
 }
 
-// Load reads spellchecker data from the provided reader and decodes it
+// Load reads spellchecker data from the provided reader and decodes it.
+// Only the snapshot is read: use LoadFile() or Open() to replay the journal stored next to a snapshot file
 func Load(reader io.Reader) (*Spellchecker, error) {
 	data := spellcheckerData{}
 
@@ -30,7 +55,25 @@ func Load(reader io.Reader) (*Spellchecker, error) {
 		return nil, err
 	}
 
-	return &Spellchecker{
-		dict: data.Dict,
-	}, nil
+	if data.ErrorModel != nil {
+		data.Dict.scoreFunc = data.ErrorModel.ScoreFunc()
+	}
+
+	s := &Spellchecker{
+		dict:       data.Dict,
+		learned:    data.Learned,
+		errorModel: data.ErrorModel,
+
+		normalization: data.Normalization,
+		minCount:      data.MinCount,
+		maxVocabulary: data.MaxVocabulary,
+
+		generation: data.Generation,
+	}
+	// transliteration indexes are not saved, they are rebuilt from the dictionary
+	if err := s.indexTransliterations(data.Transliterations); err != nil {
+		return nil, err
+	}
+
+	return s, nil
 }
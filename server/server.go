@@ -0,0 +1,372 @@
+// Package server exposes a spellchecker over HTTP with JSON payloads
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/f1monkey/spellchecker"
+)
+
+const (
+	// DefaultMaxBodySize max request body size in bytes
+	DefaultMaxBodySize = 1 << 20
+	// DefaultSuggestions number of suggestions returned if a request does not specify it
+	DefaultSuggestions = 5
+	// MaxSuggestions max number of suggestions a request may ask for
+	MaxSuggestions = 100
+)
+
+// OptionFunc option setter
+type OptionFunc func(s *Server) error
+
+// Server is an http.Handler serving the spellchecker. It wraps the provided spellchecker without copying it
+type Server struct {
+	mtx     sync.RWMutex
+	checker *spellchecker.Spellchecker
+	// owned the served spellchecker was opened by Reload() and must be closed by the server
+	owned bool
+
+	path        string
+	checkerOpts []spellchecker.OptionFunc
+	maxBodySize int64
+	suggestions int
+
+	mux *http.ServeMux
+}
+
+// New create a server for the spellchecker
+func New(checker *spellchecker.Spellchecker, opts ...OptionFunc) (*Server, error) {
+	s := &Server{
+		checker:     checker,
+		maxBodySize: DefaultMaxBodySize,
+		suggestions: DefaultSuggestions,
+		mux:         http.NewServeMux(),
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mux.HandleFunc("/check", s.post(s.handleCheck))
+	s.mux.HandleFunc("/fix", s.post(s.handleFix))
+	s.mux.HandleFunc("/suggest", s.post(s.handleSuggest))
+	s.mux.HandleFunc("/words", s.handleWords)
+	s.mux.HandleFunc("/reload", s.post(s.handleReload))
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+
+	return s, nil
+}
+
+// WithMaxBodySize set max request body size in bytes
+func WithMaxBodySize(n int64) OptionFunc {
+	return func(s *Server) error {
+		if n <= 0 {
+			return fmt.Errorf("max body size must be positive, got %d", n)
+		}
+		s.maxBodySize = n
+		return nil
+	}
+}
+
+// WithSuggestions set number of suggestions returned if a request does not specify it
+func WithSuggestions(n int) OptionFunc {
+	return func(s *Server) error {
+		if n <= 0 || n > MaxSuggestions {
+			return fmt.Errorf("number of suggestions must be in range 1..%d, got %d", MaxSuggestions, n)
+		}
+		s.suggestions = n
+		return nil
+	}
+}
+
+// WithDictionaryFile set the saved dictionary file used by Reload(). The file is opened with spellchecker.Open(),
+// so changes made with /words are appended to its journal and survive reloads. opts are applied to every reloaded spellchecker.
+// The served spellchecker must be opened from the same file, otherwise /words changes are rejected
+func WithDictionaryFile(path string, opts ...spellchecker.OptionFunc) OptionFunc {
+	return func(s *Server) error {
+		s.path = path
+		s.checkerOpts = opts
+		return nil
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Checker returns the currently served spellchecker
+func (s *Server) Checker() *spellchecker.Spellchecker {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.checker
+}
+
+// Reload opens the dictionary file (replaying its journal) and swaps the served spellchecker.
+// Requests in progress are completed with the previous spellchecker
+func (s *Server) Reload() error {
+	if s.path == "" {
+		return fmt.Errorf("dictionary file is not configured")
+	}
+
+	// word changes wait for the reload, so none of them is written to the journal after it is replayed
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	checker, err := spellchecker.Open(s.path, s.checkerOpts...)
+	if err != nil {
+		return err
+	}
+
+	old, owned := s.checker, s.owned
+	s.checker, s.owned = checker, true
+	if owned {
+		return old.Close()
+	}
+
+	return nil
+}
+
+// Close releases the journal of the spellchecker opened by Reload(). The spellchecker passed to New() is not closed
+func (s *Server) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.owned {
+		return nil
+	}
+
+	return s.checker.Close()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type checkRequest struct {
+	Text string `json:"text"`
+	// Suggestions number of suggestions for every misspelled word
+	Suggestions int `json:"suggestions"`
+}
+
+type misspelling struct {
+	Word        string   `json:"word"`
+	Offset      int      `json:"offset"`
+	Suggestions []string `json:"suggestions"`
+}
+
+type checkResponse struct {
+	Misspellings []misspelling `json:"misspellings"`
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	req := checkRequest{}
+	if !s.decode(w, r, &req) {
+		return
+	}
+	n, ok := s.suggestionsNum(w, req.Suggestions)
+	if !ok {
+		return
+	}
+
+	checker := s.Checker()
+	resp := checkResponse{Misspellings: []misspelling{}}
+	for _, m := range checker.CheckText(req.Text) {
+		suggestions, err := checker.Suggest(m.Word, n)
+		if err != nil {
+			suggestions = []string{}
+		}
+		resp.Misspellings = append(resp.Misspellings, misspelling{
+			Word:        m.Word,
+			Offset:      m.Offset,
+			Suggestions: suggestions,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type fixRequest struct {
+	Text string `json:"text"`
+}
+
+type fixResponse struct {
+	Text string `json:"text"`
+}
+
+func (s *Server) handleFix(w http.ResponseWriter, r *http.Request) {
+	req := fixRequest{}
+	if !s.decode(w, r, &req) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, fixResponse{Text: s.Checker().FixText(req.Text)})
+}
+
+type suggestRequest struct {
+	Word string `json:"word"`
+	// Suggestions number of suggestions
+	Suggestions int `json:"suggestions"`
+}
+
+type suggestResponse struct {
+	Word        string   `json:"word"`
+	Correct     bool     `json:"correct"`
+	Suggestions []string `json:"suggestions"`
+}
+
+func (s *Server) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	req := suggestRequest{}
+	if !s.decode(w, r, &req) {
+		return
+	}
+	if req.Word == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "word is required"})
+		return
+	}
+	n, ok := s.suggestionsNum(w, req.Suggestions)
+	if !ok {
+		return
+	}
+
+	checker := s.Checker()
+	resp := suggestResponse{
+		Word:        req.Word,
+		Correct:     checker.IsCorrect(req.Word),
+		Suggestions: []string{},
+	}
+	if suggestions, err := checker.Suggest(req.Word, n); err == nil {
+		resp.Suggestions = suggestions
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type wordsRequest struct {
+	Words []string `json:"words"`
+}
+
+// handleWords adds (POST) or removes (DELETE) words
+func (s *Server) handleWords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		w.Header().Set("Allow", "POST, DELETE")
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return
+	}
+
+	req := wordsRequest{}
+	if !s.decode(w, r, &req) {
+		return
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	// changes of a spellchecker without the journal of the dictionary file would be lost on reload
+	if s.path != "" && !sameFile(s.checker.Path(), s.path) {
+		writeJSON(w, http.StatusConflict, errorResponse{Error: "the spellchecker is not opened from the dictionary file, changes would be lost on reload"})
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		s.checker.Add(req.Words...)
+	} else {
+		s.checker.Remove(req.Words...)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if err := s.Reload(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type healthzResponse struct {
+	Status string `json:"status"`
+	Words  int    `json:"words"`
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, healthzResponse{
+		Status: "ok",
+		Words:  s.Checker().Len(),
+	})
+}
+
+func (s *Server) post(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+		h(w, r)
+	}
+}
+
+// decode reads the request body as JSON. Writes an error response and returns false on failure
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxBodySize)
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: err.Error()})
+		return false
+	}
+	writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+
+	return false
+}
+
+// suggestionsNum validates number of suggestions requested. Writes an error response and returns false on failure
+func (s *Server) suggestionsNum(w http.ResponseWriter, n int) (int, bool) {
+	if n == 0 {
+		return s.suggestions, true
+	}
+	if n < 0 || n > MaxSuggestions {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("suggestions must be in range 1..%d", MaxSuggestions),
+		})
+		return 0, false
+	}
+
+	return n, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func sameFile(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(infoA, infoB)
+}
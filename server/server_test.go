@@ -0,0 +1,192 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/f1monkey/spellchecker"
+	"github.com/stretchr/testify/require"
+)
+
+func newChecker(t *testing.T) *spellchecker.Spellchecker {
+	s, err := spellchecker.New(spellchecker.DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("orange", "orange", "range", "green", "tea", "problem")
+
+	return s
+}
+
+func newTestServer(t *testing.T, opts ...OptionFunc) *httptest.Server {
+	s, err := New(newChecker(t), opts...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func do(t *testing.T, ts *httptest.Server, method, path, body string, result interface{}) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if result != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+
+	return resp.StatusCode
+}
+
+func Test_Server_check(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp := checkResponse{}
+	status := do(t, ts, http.MethodPost, "/check", `{"text": "green tea, arang", "suggestions": 1}`, &resp)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []misspelling{{Word: "arang", Offset: 11, Suggestions: []string{"orange"}}}, resp.Misspellings)
+
+	errResp := errorResponse{}
+	status = do(t, ts, http.MethodPost, "/check", `{"text": "arang", "suggestions": 1000}`, &errResp)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.NotEmpty(t, errResp.Error)
+}
+
+func Test_Server_fix(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp := fixResponse{}
+	status := do(t, ts, http.MethodPost, "/fix", `{"text": "green tea: problam"}`, &resp)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "green tea: problem", resp.Text)
+
+	status = do(t, ts, http.MethodGet, "/fix", "", nil)
+	require.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func Test_Server_suggest(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp := suggestResponse{}
+	status := do(t, ts, http.MethodPost, "/suggest", `{"word": "arang"}`, &resp)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, suggestResponse{Word: "arang", Suggestions: []string{"orange", "range"}}, resp)
+
+	resp = suggestResponse{}
+	status = do(t, ts, http.MethodPost, "/suggest", `{"word": "xyzxyz"}`, &resp)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, suggestResponse{Word: "xyzxyz", Suggestions: []string{}}, resp)
+
+	status = do(t, ts, http.MethodPost, "/suggest", `{"word": `, nil)
+	require.Equal(t, http.StatusBadRequest, status)
+}
+
+func Test_Server_words(t *testing.T) {
+	ts := newTestServer(t)
+
+	status := do(t, ts, http.MethodPost, "/words", `{"words": ["kubernetes"]}`, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	resp := suggestResponse{}
+	do(t, ts, http.MethodPost, "/suggest", `{"word": "kubernetes"}`, &resp)
+	require.True(t, resp.Correct)
+
+	status = do(t, ts, http.MethodDelete, "/words", `{"words": ["kubernetes"]}`, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	resp = suggestResponse{}
+	do(t, ts, http.MethodPost, "/suggest", `{"word": "kubernetes"}`, &resp)
+	require.False(t, resp.Correct)
+}
+
+func Test_Server_maxBodySize(t *testing.T) {
+	ts := newTestServer(t, WithMaxBodySize(16))
+
+	status := do(t, ts, http.MethodPost, "/fix", `{"text": "green tea green tea"}`, nil)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
+
+func Test_Server_Reload(t *testing.T) {
+	dictPath := filepath.Join(t.TempDir(), "dict.bin")
+	save := func(words ...string) {
+		s := newChecker(t)
+		s.Add(words...)
+		f, err := os.Create(dictPath)
+		require.NoError(t, err)
+		require.NoError(t, s.Save(f))
+		require.NoError(t, f.Close())
+	}
+	save()
+
+	s, err := New(newChecker(t), WithDictionaryFile(dictPath))
+	require.NoError(t, err)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	health := healthzResponse{}
+	require.Equal(t, http.StatusOK, do(t, ts, http.MethodGet, "/healthz", "", &health))
+	require.Equal(t, healthzResponse{Status: "ok", Words: 5}, health)
+
+	save("kubernetes")
+	require.Equal(t, http.StatusNoContent, do(t, ts, http.MethodPost, "/reload", "", nil))
+
+	require.Equal(t, http.StatusOK, do(t, ts, http.MethodGet, "/healthz", "", &health))
+	require.Equal(t, 6, health.Words)
+
+	t.Run("must keep added words after reload", func(t *testing.T) {
+		save()
+		checker, err := spellchecker.Open(dictPath)
+		require.NoError(t, err)
+		defer checker.Close()
+
+		s, err := New(checker, WithDictionaryFile(dictPath, spellchecker.WithIgnoreRules(spellchecker.IgnoreURLs())))
+		require.NoError(t, err)
+		defer s.Close()
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+
+		require.Equal(t, http.StatusNoContent, do(t, ts, http.MethodPost, "/words", `{"words": ["kubernetes"]}`, nil))
+		require.Equal(t, http.StatusNoContent, do(t, ts, http.MethodPost, "/reload", "", nil))
+		require.Equal(t, http.StatusNoContent, do(t, ts, http.MethodDelete, "/words", `{"words": ["tea"]}`, nil))
+		require.Equal(t, http.StatusNoContent, do(t, ts, http.MethodPost, "/reload", "", nil))
+
+		resp := suggestResponse{}
+		do(t, ts, http.MethodPost, "/suggest", `{"word": "kubernetes"}`, &resp)
+		require.True(t, resp.Correct)
+		resp = suggestResponse{}
+		do(t, ts, http.MethodPost, "/suggest", `{"word": "tea"}`, &resp)
+		require.False(t, resp.Correct)
+
+		// options are applied to the reloaded spellchecker
+		check := checkResponse{}
+		do(t, ts, http.MethodPost, "/check", `{"text": "green https://example.com"}`, &check)
+		require.Empty(t, check.Misspellings)
+	})
+
+	t.Run("must reject word changes which would be lost on reload", func(t *testing.T) {
+		save()
+		s, err := New(newChecker(t), WithDictionaryFile(dictPath))
+		require.NoError(t, err)
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+
+		errResp := errorResponse{}
+		require.Equal(t, http.StatusConflict, do(t, ts, http.MethodPost, "/words", `{"words": ["kubernetes"]}`, &errResp))
+		require.NotEmpty(t, errResp.Error)
+	})
+
+	t.Run("must fail if dictionary file is not configured", func(t *testing.T) {
+		s, err := New(newChecker(t))
+		require.NoError(t, err)
+		require.Error(t, s.Reload())
+	})
+}
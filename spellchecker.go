@@ -17,9 +17,29 @@ type Spellchecker struct {
 	mtx sync.RWMutex
 
 	dict      *dictionary
-	splitter  bufio.SplitFunc
+	tokenizer Tokenizer
 	scoreFunc scoreFunc
 	maxErrors int
+
+	journal *journal
+	path    string
+
+	learned    learned
+	errorModel *ErrorModel
+
+	normalization Normalization
+	layouts       []layoutMap
+	translits     []*translitIndex
+
+	ignoreRules []IgnoreRule
+
+	// minCount minimum occurrence counter of a correct word
+	minCount int
+	// maxVocabulary max number of words kept after AddFrom() and Prune(), 0 means no limit
+	maxVocabulary int
+
+	// generation number of the snapshot, incremented by Compact()
+	generation uint64
 }
 
 func New(alphabet string, opts ...OptionFunc) (*Spellchecker, error) {
@@ -42,93 +62,191 @@ func New(alphabet string, opts ...OptionFunc) (*Spellchecker, error) {
 	return result, nil
 }
 
-// AddFrom reads input, splits it with spellchecker splitter func and adds words to dictionary
+// AddFrom reads input, splits it with spellchecker tokenizer and adds normalized words to dictionary
 func (m *Spellchecker) AddFrom(input io.Reader) error {
+	m.mtx.RLock()
+	tokenizer := ignoreTokenizer(m.tokenizer, m.ignoreRules, nil)
+	m.mtx.RUnlock()
+
 	words := make([]string, 1000)
 	i := 0
-	for item := range readInput(input, m.splitter) {
-		if item.err != nil {
-			return item.err
-		}
-
+	err := tokenizer.Tokenize(input, func(t Token) bool {
 		if i == len(words) {
 			m.Add(words...)
 			i = 0
 		}
-		words[i] = item.word
+		words[i] = t.Normalized
 		i++
+		return true
+	})
+	if err != nil {
+		return err
 	}
 
 	if i > 0 {
 		m.Add(words[:i]...)
 	}
+	m.enforceVocabulary()
 
 	return nil
 }
 
-// Add adds provided words to dictionary
+// Add adds provided words to dictionary. Words are stored normalized and case-folded
 func (m *Spellchecker) Add(words ...string) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	m.add(words...)
+	m.journal.write(opAdd, words...)
+}
+
+func (m *Spellchecker) add(words ...string) {
 	for _, word := range words {
-		if id := m.dict.id(word); id > 0 {
+		key := m.normalize(word)
+		if id := m.dict.id(key); id > 0 {
 			m.dict.inc(id)
 			continue
 		}
 
-		m.dict.add(word)
+		id, _ := m.dict.add(key)
+		if original := m.normalization.original(word); original != key {
+			m.dict.originals[id] = original
+		}
+		for _, ti := range m.translits {
+			ti.add(id, key)
+		}
+	}
+}
+
+// set changes the word occurence counter, adding or removing the word if needed
+func (m *Spellchecker) set(word string, count int) {
+	if count < 1 {
+		m.remove(word)
+		return
+	}
+
+	key := m.normalize(word)
+	if m.dict.id(key) == 0 {
+		m.add(word)
+	}
+	m.dict.set(key, count)
+}
+
+// Remove deletes provided words from dictionary
+func (m *Spellchecker) Remove(words ...string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.remove(words...)
+	m.journal.write(opRemove, words...)
+}
+
+func (m *Spellchecker) remove(words ...string) {
+	for _, word := range words {
+		key := m.normalize(word)
+		if id := m.dict.id(key); id > 0 {
+			for _, ti := range m.translits {
+				ti.remove(id, key)
+			}
+			m.dict.remove(id)
+		}
 	}
 }
 
 var ErrUnknownWord = fmt.Errorf("unknown word")
 
-// IsCorrect check if provided word is in the dictionary
+// IsCorrect check if provided word is in the dictionary.
+// Lower case, title case and upper case forms of a word are correct, case-sensitive words are correct in their own case only
 func (s *Spellchecker) IsCorrect(word string) bool {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	return s.dict.has(word)
+	return s.isCorrect(word, s.normalize(word))
 }
 
+// Fix find the best suggestion for the word. The suggestion keeps the case of the input: "Teh" => "The"
 func (s *Spellchecker) Fix(word string) (string, error) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	if s.dict.has(word) {
-		return word, nil
+	folded := s.normalize(word)
+	if s.known(folded) {
+		return s.withCase(word, folded), nil
+	}
+
+	if corrections := s.learned.corrections(folded); len(corrections) > 0 {
+		return s.withCase(word, corrections[0]), nil
 	}
 
-	hits := s.dict.find(word, 1)
+	if switched := s.switchLayout(word); len(switched) > 0 {
+		return s.withCase(word, switched[0]), nil
+	}
+
+	hits := s.findReplacements(folded, 1)
 	if len(hits) == 0 {
 		return word, ErrUnknownWord
 	}
 
-	return hits[0].Value, nil
+	return s.withCase(word, hits[0].Value), nil
 }
 
-// Suggest find top n suggestions for the word
+// Suggest find top n suggestions for the word. Suggestions keep the case of the input
 func (s *Spellchecker) Suggest(word string, n int) ([]string, error) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	if s.dict.has(word) {
-		return []string{word}, nil
+	folded := s.normalize(word)
+	if s.known(folded) {
+		return []string{s.withCase(word, folded)}, nil
 	}
 
-	hits := s.dict.find(word, n)
-	if len(hits) == 0 {
+	// learned corrections and words typed with a wrong keyboard layout go first
+	corrections := append(s.learned.corrections(folded), s.switchLayout(word)...)
+	hits := s.findReplacements(folded, n)
+	if len(hits) == 0 && len(corrections) == 0 {
 		return []string{word}, ErrUnknownWord
 	}
 
-	result := make([]string, len(hits))
-	for i, h := range hits {
-		result[i] = h.Value
+	result := make([]string, 0, len(hits)+len(corrections))
+	seen := make(map[string]struct{}, len(corrections))
+	for _, c := range corrections {
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		result = append(result, s.withCase(word, c))
+		seen[c] = struct{}{}
+	}
+	for _, h := range hits {
+		if _, ok := seen[h.Value]; !ok {
+			result = append(result, s.withCase(word, h.Value))
+		}
+	}
+	if len(result) > n {
+		result = result[:n]
 	}
 
 	return result, nil
 }
 
+// matches find top n scored candidates for the word. Candidates for which skip returns true are dropped,
+// skip receives normalized candidates. Remaining candidates keep the case of the word
+func (s *Spellchecker) matches(word string, n int, skip func(normalized string) bool) []match {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	hits := s.find(s.normalize(word), n)
+	result := hits[:0]
+	for _, h := range hits {
+		if skip(h.Value) {
+			continue
+		}
+		h.Value = s.withCase(word, h.Value)
+		result = append(result, h)
+	}
+
+	return result
+}
+
 // WithOpt set spellchecker options
 func (s *Spellchecker) WithOpts(opts ...OptionFunc) error {
 	s.mtx.Lock()
@@ -143,19 +261,25 @@ func (s *Spellchecker) WithOpts(opts ...OptionFunc) error {
 	return nil
 }
 
-// WithSplitter set splitter func for AddFrom() reader
+// WithSplitter set splitter func used to tokenize texts.
+//
+// Deprecated: use WithTokenizer(SplitFuncTokenizer(f)) or any other tokenizer
 func WithSplitter(f bufio.SplitFunc) OptionFunc {
 	return func(s *Spellchecker) error {
-		s.splitter = f
+		s.tokenizer = SplitFuncTokenizer(f)
 		return nil
 	}
 }
 
 // WithMaxErrors set maxErrors, which is a max diff in bits betweeen the "search word" and a "dictionary word".
-// i.e. one simple symbol replacement (problam => problem ) is a two-bit difference
+// i.e. one simple symbol replacement (problam => problem ) is a two-bit difference. The value is saved along with the dictionary
 func WithMaxErrors(maxErrors int) OptionFunc {
 	return func(s *Spellchecker) error {
 		s.maxErrors = maxErrors
+		s.dict.maxErrors = maxErrors
+		for _, ti := range s.translits {
+			ti.dict.maxErrors = maxErrors
+		}
 		return nil
 	}
 }
@@ -2,6 +2,7 @@ package spellchecker
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -74,6 +75,14 @@ func newSampleSpellchecker() *Spellchecker {
 	return s
 }
 
+func newTestSpellchecker(t *testing.T, words ...string) *Spellchecker {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add(words...)
+
+	return s
+}
+
 func Benchmark_Spellchecker_AddFrom(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		newFullSpellchecker()
@@ -124,15 +133,42 @@ func Benchmark_Norvig2(b *testing.B) {
 	benchmarkNorvig(b, "data/norvig2.txt")
 }
 
+// error model is trained on the other test set to avoid overfitting
+func Benchmark_Norvig1_ErrorModel(b *testing.B) {
+	benchmarkNorvig(b, "data/norvig1.txt", WithErrorModel(trainErrorModel("data/norvig2.txt")))
+}
+
+func Benchmark_Norvig2_ErrorModel(b *testing.B) {
+	benchmarkNorvig(b, "data/norvig2.txt", WithErrorModel(trainErrorModel("data/norvig1.txt")))
+}
+
+func trainErrorModel(dataPath string) *ErrorModel {
+	f, err := os.Open(dataPath)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	m := NewErrorModel()
+	if err := m.TrainFrom(f); err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
 type benchmarkNorvigItem struct {
 	expected string
 	words    []string
 }
 
-func benchmarkNorvig(b *testing.B, dataPath string) {
+func benchmarkNorvig(b *testing.B, dataPath string, opts ...OptionFunc) {
 	b.StopTimer()
 	b.ResetTimer()
 	m := loadFullSpellchecker()
+	if err := m.WithOpts(opts...); err != nil {
+		panic(err)
+	}
 
 	testData, err := os.Open(dataPath)
 	if err != nil {
@@ -211,7 +247,7 @@ func Test_NewSpellchecker(t *testing.T) {
 	t.Run("must be able to create a spellchecker with custom splitter", func(t *testing.T) {
 		s, err := New(DefaultAlphabet, WithSplitter(bufio.ScanRunes))
 		require.NoError(t, err)
-		require.NotNil(t, s.splitter)
+		require.NotNil(t, s.tokenizer)
 	})
 }
 
@@ -219,7 +255,29 @@ func Test_Spellchecker_WithOpts(t *testing.T) {
 	s, err := New(DefaultAlphabet)
 	require.NoError(t, err)
 	s.WithOpts(WithSplitter(bufio.ScanLines))
-	require.NotNil(t, s.splitter)
+	require.NotNil(t, s.tokenizer)
+}
+
+func Test_WithMaxErrors(t *testing.T) {
+	s, err := New(DefaultAlphabet, WithMaxErrors(1))
+	require.NoError(t, err)
+	s.Add("problem")
+
+	result, err := s.Fix("problam")
+	require.NoError(t, err)
+	require.Equal(t, "problem", result)
+
+	_, err = s.Fix("porblem")
+	require.ErrorIs(t, err, ErrUnknownWord)
+
+	t.Run("must save the value", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.Save(buf))
+		s2, err := Load(buf)
+		require.NoError(t, err)
+		_, err = s2.Fix("porblem")
+		require.ErrorIs(t, err, ErrUnknownWord)
+	})
 }
 
 func Test_Spellchecker_IsCorrect(t *testing.T) {
@@ -242,3 +300,13 @@ func Test_Spellchecker_Suggest(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []string{"orange", "range"}, result)
 }
+
+func Test_Spellchecker_Remove(t *testing.T) {
+	s := newSampleSpellchecker()
+
+	s.Remove("orange", "car")
+	assert.False(t, s.IsCorrect("orange"))
+	result, err := s.Suggest("arang", 5)
+	require.NoError(t, err)
+	require.Equal(t, []string{"range"}, result)
+}
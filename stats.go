@@ -0,0 +1,138 @@
+package spellchecker
+
+import "unsafe"
+
+// Stats describes dictionary contents
+type Stats struct {
+	// Words number of unique words
+	Words int
+	// TotalCount sum of all word occurrence counters
+	TotalCount int
+	// AlphabetSize number of symbols in the alphabet
+	AlphabetSize int
+	// AlphabetUsed number of alphabet symbols present in at least one word
+	AlphabetUsed int
+	// Buckets number of buckets in the letter bitmap index
+	Buckets int
+	// BucketSizes number of index buckets by bucket size
+	BucketSizes map[int]int
+	// MaxBucketSize number of words in the largest index bucket
+	MaxBucketSize int
+	// MemoryBytes approximate memory used by words, counters and indexes. Map overheads are estimated
+	MemoryBytes int
+}
+
+// Stats returns dictionary statistics
+func (s *Spellchecker) Stats() Stats {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	d := s.dict
+	result := Stats{
+		Words:        len(d.ids),
+		AlphabetSize: d.alphabet.len(),
+		Buckets:      len(d.index),
+		BucketSizes:  make(map[int]int),
+	}
+	for _, cnt := range d.counts {
+		result.TotalCount += cnt
+	}
+
+	used := make(map[rune]struct{}, d.alphabet.len())
+	for _, word := range d.words {
+		for _, r := range word {
+			if _, ok := d.alphabet[r]; ok {
+				used[r] = struct{}{}
+			}
+		}
+	}
+	result.AlphabetUsed = len(used)
+
+	for _, ids := range d.index {
+		result.BucketSizes[len(ids)]++
+		if len(ids) > result.MaxBucketSize {
+			result.MaxBucketSize = len(ids)
+		}
+	}
+
+	result.MemoryBytes = d.memory()
+
+	return result
+}
+
+// mapEntryOverhead estimated memory used by a map entry besides its key and value
+const mapEntryOverhead = 8
+
+// memory estimates memory used by the dictionary data
+func (d *dictionary) memory() int {
+	const (
+		id     = int(unsafe.Sizeof(uint32(0)))
+		count  = int(unsafe.Sizeof(int(0)))
+		str    = int(unsafe.Sizeof(""))
+		slice  = int(unsafe.Sizeof([]uint32{}))
+		bucket = int(unsafe.Sizeof(uint64(0)))
+	)
+
+	result := 0
+	for _, word := range d.words {
+		// the same string is stored in words and ids
+		result += len(word) + 2*(str+id+mapEntryOverhead)
+	}
+	result += len(d.counts) * (id + count + mapEntryOverhead)
+	for _, form := range d.forms {
+		result += len(form) + str + id + mapEntryOverhead
+	}
+	for _, original := range d.originals {
+		result += len(original) + str + id + mapEntryOverhead
+	}
+	for _, ids := range d.index {
+		result += bucket + slice + cap(ids)*id + mapEntryOverhead
+	}
+	result += cap(d.sorted) * id
+
+	return result
+}
+
+// Len returns the number of unique words
+func (s *Spellchecker) Len() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return len(s.dict.ids)
+}
+
+// TotalCount returns the sum of all word occurrence counters
+func (s *Spellchecker) TotalCount() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	result := 0
+	for _, cnt := range s.dict.counts {
+		result += cnt
+	}
+
+	return result
+}
+
+// Count returns the occurrence counter of the word, 0 if the word is not in the dictionary
+func (s *Spellchecker) Count(word string) int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.dict.counts[s.dict.id(s.normalize(word))]
+}
+
+// Words calls fn for every dictionary word in lexicographical order of normalized words.
+// Words are passed in their cased or original forms. Stops when fn returns false.
+// fn must not modify the spellchecker
+func (s *Spellchecker) Words(fn func(word string, count int) bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for _, id := range s.dict.sortedIDs() {
+		wc := s.wordCount(id)
+		if !fn(wc.Word, wc.Count) {
+			return
+		}
+	}
+}
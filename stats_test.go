@@ -0,0 +1,79 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_Stats(t *testing.T) {
+	s := newSampleSpellchecker()
+
+	stats := s.Stats()
+	require.Equal(t, 11, stats.Words)
+	require.Equal(t, 17, stats.TotalCount)
+	require.Equal(t, 26, stats.AlphabetSize)
+	require.Greater(t, stats.MemoryBytes, 0)
+
+	buckets, words := 0, 0
+	for size, cnt := range stats.BucketSizes {
+		require.LessOrEqual(t, size, stats.MaxBucketSize)
+		buckets += cnt
+		words += size * cnt
+	}
+	require.Equal(t, stats.Buckets, buckets)
+	require.Equal(t, stats.Words, words)
+}
+
+func Test_Spellchecker_Stats_Buckets(t *testing.T) {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("listen", "silent", "tinsel", "tea", "eat", "green")
+
+	stats := s.Stats()
+	require.Equal(t, 3, stats.Buckets)
+	require.Equal(t, map[int]int{3: 1, 2: 1, 1: 1}, stats.BucketSizes)
+	require.Equal(t, 3, stats.MaxBucketSize)
+	// l, i, s, t, e, n, a, g, r
+	require.Equal(t, 9, stats.AlphabetUsed)
+
+	before := stats.MemoryBytes
+	s.Add("orange")
+	require.Greater(t, s.Stats().MemoryBytes, before)
+}
+
+func Test_Spellchecker_Words(t *testing.T) {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	s.Add("tea", "tea", "green", "black")
+	s.AddCased("London")
+
+	t.Run("must iterate words in order", func(t *testing.T) {
+		var words []string
+		var counts []int
+		s.Words(func(word string, count int) bool {
+			words = append(words, word)
+			counts = append(counts, count)
+			return true
+		})
+		require.Equal(t, []string{"black", "green", "London", "tea"}, words)
+		require.Equal(t, []int{1, 1, 1, 2}, counts)
+	})
+
+	t.Run("must stop iteration", func(t *testing.T) {
+		var words []string
+		s.Words(func(word string, _ int) bool {
+			words = append(words, word)
+			return false
+		})
+		require.Equal(t, []string{"black"}, words)
+	})
+
+	t.Run("must count words", func(t *testing.T) {
+		require.Equal(t, 4, s.Len())
+		require.Equal(t, 5, s.TotalCount())
+		require.Equal(t, 2, s.Count("tea"))
+		require.Equal(t, 2, s.Count("TEA"))
+		require.Equal(t, 0, s.Count("coffee"))
+	})
+}
@@ -0,0 +1,72 @@
+package spellchecker
+
+import (
+	"strings"
+)
+
+// Misspelling is a word missing in the dictionary found in a text
+type Misspelling struct {
+	Token
+}
+
+// CheckText splits the text with spellchecker tokenizer and returns words missing in the dictionary.
+// Tokens matching the ignore rules are skipped
+func (s *Spellchecker) CheckText(text string) []Misspelling {
+	return s.CheckTextWith(text, nil)
+}
+
+// CheckTextWith splits the text with the tokenizer (i.e. a markup-aware one) and returns words missing in the dictionary.
+// Spellchecker tokenizer is used if t is nil. Tokens without letters are not checked
+func (s *Spellchecker) CheckTextWith(text string, t Tokenizer) []Misspelling {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if t == nil {
+		t = s.tokenizer
+	}
+
+	var result []Misspelling
+	for _, token := range tokenize(ignoreTokenizer(t, s.ignoreRules, nil), text) {
+		if hasLetter(token.Word) && !s.isCorrect(token.Word, s.normalize(token.Normalized)) {
+			result = append(result, Misspelling{Token: token})
+		}
+	}
+
+	return result
+}
+
+// FixText replaces misspelled words in the text with the best suggestions.
+// Words without suggestions are left unchanged
+func (s *Spellchecker) FixText(text string) string {
+	return s.FixTextWith(text, nil)
+}
+
+// FixTextWith replaces misspelled words found by the tokenizer with the best suggestions.
+// Spellchecker tokenizer is used if t is nil
+func (s *Spellchecker) FixTextWith(text string, t Tokenizer) string {
+	misspellings := s.CheckTextWith(text, t)
+	if len(misspellings) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(text))
+	prev := 0
+	for _, m := range misspellings {
+		end := m.Offset + len(m.Word)
+		// tokenizers may return words which differ from the text (i.e. split funcs)
+		if end > len(text) || text[m.Offset:end] != m.Word {
+			continue
+		}
+		fixed, err := s.Fix(m.Word)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(text[prev:m.Offset])
+		sb.WriteString(fixed)
+		prev = end
+	}
+	sb.WriteString(text[prev:])
+
+	return sb.String()
+}
@@ -0,0 +1,27 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Spellchecker_CheckText(t *testing.T) {
+	s := newSampleSpellchecker()
+
+	// tokens without letters are not checked
+	result := s.CheckText("Green tea -- and an oragne, problam!")
+	require.Equal(t, []Misspelling{
+		{Token{Word: "and", Normalized: "and", Offset: 13}},
+		{Token{Word: "an", Normalized: "an", Offset: 17}},
+		{Token{Word: "oragne", Normalized: "oragne", Offset: 20}},
+		{Token{Word: "problam", Normalized: "problam", Offset: 28}},
+	}, result)
+}
+
+func Test_Spellchecker_FixText(t *testing.T) {
+	s := newSampleSpellchecker()
+
+	require.Equal(t, "green tea: orange, problem!", s.FixText("green tea: oragne, problam!"))
+	require.Equal(t, "green tea", s.FixText("green tea"))
+}
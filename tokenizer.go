@@ -0,0 +1,322 @@
+package spellchecker
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a word found in a text
+type Token struct {
+	// Word is the word as it appears in the text
+	Word string
+	// Normalized is the word prepared for dictionary lookup (i.e. lowercased)
+	Normalized string
+	// Offset is a byte offset of the word in the text
+	Offset int
+}
+
+// Tokenizer splits a text into tokens
+type Tokenizer interface {
+	// Tokenize reads the text from r and calls fn for every token in order. Stops when fn returns false
+	Tokenize(r io.Reader, fn func(Token) bool) error
+}
+
+// TokenizerFunc is an adapter to use ordinary functions as tokenizers
+type TokenizerFunc func(r io.Reader, fn func(Token) bool) error
+
+func (f TokenizerFunc) Tokenize(r io.Reader, fn func(Token) bool) error {
+	return f(r, fn)
+}
+
+// WithTokenizer set tokenizer used by AddFrom() and text checking
+func WithTokenizer(t Tokenizer) OptionFunc {
+	return func(s *Spellchecker) error {
+		s.tokenizer = t
+		return nil
+	}
+}
+
+// Tokens splits the text with spellchecker tokenizer skipping tokens matching the ignore rules
+func (s *Spellchecker) Tokens(text string) []Token {
+	s.mtx.RLock()
+	tokenizer := ignoreTokenizer(s.tokenizer, s.ignoreRules, nil)
+	s.mtx.RUnlock()
+
+	return tokenize(tokenizer, text)
+}
+
+func tokenize(t Tokenizer, text string) []Token {
+	if t == nil {
+		t = defaultTokenizer
+	}
+
+	var result []Token
+	// reading from strings.Reader never fails
+	_ = t.Tokenize(strings.NewReader(text), func(t Token) bool {
+		result = append(result, t)
+		return true
+	})
+
+	return result
+}
+
+// defaultTokenizer is used when no tokenizer is set, it splits text the same way as the default splitter always did
+var defaultTokenizer = SplitFuncTokenizer(nil)
+
+// eachLine calls fn for every line of the input (including the line break) and its byte offset
+func eachLine(r io.Reader, fn func(line string, offset int) bool) error {
+	reader := bufio.NewReader(r)
+	offset := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if !fn(line, offset) {
+				return nil
+			}
+			offset += len(line)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func newToken(word string, offset int) Token {
+	return Token{
+		Word:       word,
+		Normalized: strings.ToLower(word),
+		Offset:     offset,
+	}
+}
+
+// WordTokenizer splits text into words by Unicode word boundaries (UAX #29).
+// Only words containing letters are returned, so numbers, punctuation and spaces are skipped.
+// Unlike UAX #29 a colon never joins letters
+func WordTokenizer() Tokenizer {
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		return eachLine(r, func(line string, offset int) bool {
+			for _, w := range segmentWords(line) {
+				if !fn(newToken(line[w[0]:w[1]], offset+w[0])) {
+					return false
+				}
+			}
+			return true
+		})
+	})
+}
+
+// WhitespaceTokenizer splits text by spaces trimming punctuation around words
+func WhitespaceTokenizer() Tokenizer {
+	trim := func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}
+
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		return eachLine(r, func(line string, offset int) bool {
+			i := 0
+			for i < len(line) {
+				start := i
+				for start < len(line) {
+					r, size := utf8.DecodeRuneInString(line[start:])
+					if !unicode.IsSpace(r) {
+						break
+					}
+					start += size
+				}
+				end := start
+				for end < len(line) {
+					r, size := utf8.DecodeRuneInString(line[end:])
+					if unicode.IsSpace(r) {
+						break
+					}
+					end += size
+				}
+				i = end
+
+				field := strings.TrimLeftFunc(line[start:end], trim)
+				start += len(line[start:end]) - len(field)
+				field = strings.TrimRightFunc(field, trim)
+				if field == "" {
+					continue
+				}
+				if !fn(newToken(field, offset+start)) {
+					return false
+				}
+			}
+			return true
+		})
+	})
+}
+
+// RegexpTokenizer returns every match of the regular expression as a token.
+// The text is matched line by line, so matches never span several lines
+func RegexpTokenizer(re *regexp.Regexp) Tokenizer {
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		return eachLine(r, func(line string, offset int) bool {
+			for _, loc := range re.FindAllStringIndex(line, -1) {
+				if loc[0] == loc[1] {
+					continue
+				}
+				if !fn(newToken(line[loc[0]:loc[1]], offset+loc[0])) {
+					return false
+				}
+			}
+			return true
+		})
+	})
+}
+
+// SplitFuncTokenizer adapts bufio.SplitFunc to the Tokenizer interface. Split func tokens are used as normalized words.
+// The default splitter is used if f is nil: it splits text by spaces, takes the first run of letters and hyphens
+// of every word and lowercases it. Words and offsets of the default splitter point to the text exactly.
+// Custom split funcs which change tokens (i.e. lowercase them) have offsets of the text parts the tokens were read from
+// and the tokens are used as original words too
+func SplitFuncTokenizer(f bufio.SplitFunc) Tokenizer {
+	return TokenizerFunc(func(r io.Reader, fn func(Token) bool) error {
+		ch := readInput(r, f)
+		for item := range ch {
+			if item.err != nil {
+				return item.err
+			}
+			if item.word == "" {
+				continue
+			}
+			word := item.original
+			if word == "" {
+				word = item.word
+			}
+			if !fn(Token{Word: word, Normalized: item.word, Offset: item.offset}) {
+				// drain the channel to let the reader goroutine exit
+				for range ch {
+				}
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+type wordBreakClass byte
+
+const (
+	wbOther wordBreakClass = iota
+	wbLetter
+	wbNumeric
+	wbKatakana
+	wbExtendNumLet
+	wbMidLetter
+	wbMidNum
+	wbMidNumLet
+	wbExtend
+)
+
+func wordBreakClassOf(r rune) wordBreakClass {
+	switch {
+	case r == '\'' || r == '.' || r == '\u2018' || r == '\u2019' || r == '\u2024' || r == '\uFE52' || r == '\uFF07' || r == '\uFF0E':
+		return wbMidNumLet
+	case r == '\u00B7' || r == '\u0387' || r == '\u05F4' || r == '\u2027' || r == '\uFE13' || r == '\uFE55' || r == '\uFF1A':
+		return wbMidLetter
+	case r == ',' || r == ';' || r == '\u037E' || r == '\u0589' || r == '\u060C' || r == '\u060D' || r == '\u066C' || r == '\u07F8' ||
+		r == '\u2044' || r == '\uFE10' || r == '\uFE14' || r == '\uFE50' || r == '\uFE54' || r == '\uFF0C' || r == '\uFF1B':
+		return wbMidNum
+	case unicode.Is(unicode.Katakana, r):
+		return wbKatakana
+	case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Thai, r) || unicode.Is(unicode.Lao, r) || unicode.Is(unicode.Khmer, r) || unicode.Is(unicode.Myanmar, r):
+		// ideographic and complex context scripts are not joined into words
+		return wbOther
+	case unicode.IsLetter(r):
+		return wbLetter
+	case unicode.Is(unicode.Nd, r):
+		return wbNumeric
+	case unicode.Is(unicode.Pc, r):
+		return wbExtendNumLet
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Cf, r):
+		return wbExtend
+	}
+
+	return wbOther
+}
+
+type classifiedRune struct {
+	class  wordBreakClass
+	offset int
+}
+
+// segmentWords returns byte ranges of words containing letters
+func segmentWords(text string) [][2]int {
+	// WB4: extend and format symbols are attached to the preceding symbol
+	runes := make([]classifiedRune, 0, len(text))
+	for offset, r := range text {
+		class := wordBreakClassOf(r)
+		if class == wbExtend && len(runes) > 0 {
+			continue
+		}
+		runes = append(runes, classifiedRune{class: class, offset: offset})
+	}
+	end := func(i int) int {
+		if i+1 < len(runes) {
+			return runes[i+1].offset
+		}
+		return len(text)
+	}
+
+	var result [][2]int
+	for i := 0; i < len(runes); {
+		c := runes[i].class
+		if c != wbLetter && c != wbNumeric && c != wbKatakana && c != wbExtendNumLet {
+			i++
+			continue
+		}
+
+		start := i
+		hasLetter := c == wbLetter || c == wbKatakana
+		for i+1 < len(runes) {
+			prev, next := runes[i].class, runes[i+1].class
+			if joins(prev, next) {
+				i++
+				hasLetter = hasLetter || next == wbLetter || next == wbKatakana
+				continue
+			}
+			// WB6, WB7, WB11, WB12: letters or digits around a middle symbol
+			if i+2 < len(runes) && runes[i+2].class == prev {
+				if (prev == wbLetter && (next == wbMidLetter || next == wbMidNumLet)) ||
+					(prev == wbNumeric && (next == wbMidNum || next == wbMidNumLet)) {
+					i += 2
+					continue
+				}
+			}
+			break
+		}
+
+		if hasLetter {
+			result = append(result, [2]int{runes[start].offset, end(i)})
+		}
+		i++
+	}
+
+	return result
+}
+
+// joins check if there is no word boundary between symbols of the classes (WB5, WB8-WB10, WB13-WB13b)
+func joins(prev, next wordBreakClass) bool {
+	switch {
+	case (prev == wbLetter || prev == wbNumeric) && (next == wbLetter || next == wbNumeric):
+		return true
+	case prev == wbKatakana && next == wbKatakana:
+		return true
+	case next == wbExtendNumLet && (prev == wbLetter || prev == wbNumeric || prev == wbKatakana || prev == wbExtendNumLet):
+		return true
+	case prev == wbExtendNumLet && (next == wbLetter || next == wbNumeric || next == wbKatakana):
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package spellchecker
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WordTokenizer(t *testing.T) {
+	words := func(text string) []string {
+		var result []string
+		for _, t := range tokenize(WordTokenizer(), text) {
+			result = append(result, t.Word)
+		}
+		return result
+	}
+
+	t.Run("must join letters around apostrophes and dots", func(t *testing.T) {
+		require.Equal(t, []string{"Don't", "e.g", "co", "op"}, words("Don't -- e.g. co-op"))
+	})
+
+	t.Run("must skip numbers but keep words with digits", func(t *testing.T) {
+		require.Equal(t, []string{"v1.2a", "snake_case", "abc123"}, words("3.14 1,000 v1.2a snake_case abc123"))
+	})
+
+	t.Run("must keep combining marks", func(t *testing.T) {
+		require.Equal(t, []string{"café", "über"}, words("café, über!"))
+	})
+
+	t.Run("must not join words with a colon", func(t *testing.T) {
+		require.Equal(t, []string{"right", "wrong"}, words("right:wrong"))
+	})
+
+	t.Run("must return offsets and normalized words", func(t *testing.T) {
+		result := tokenize(WordTokenizer(), "Green tea\nBlack  TEA")
+		require.Equal(t, []Token{
+			{Word: "Green", Normalized: "green", Offset: 0},
+			{Word: "tea", Normalized: "tea", Offset: 6},
+			{Word: "Black", Normalized: "black", Offset: 10},
+			{Word: "TEA", Normalized: "tea", Offset: 17},
+		}, result)
+	})
+}
+
+func Test_WhitespaceTokenizer(t *testing.T) {
+	result := tokenize(WhitespaceTokenizer(), "  (Green) tea!\n -- don't")
+	require.Equal(t, []Token{
+		{Word: "Green", Normalized: "green", Offset: 3},
+		{Word: "tea", Normalized: "tea", Offset: 10},
+		{Word: "don't", Normalized: "don't", Offset: 19},
+	}, result)
+}
+
+func Test_RegexpTokenizer(t *testing.T) {
+	result := tokenize(RegexpTokenizer(regexp.MustCompile(`[a-z]+`)), "Green tea")
+	require.Equal(t, []Token{
+		{Word: "reen", Normalized: "reen", Offset: 1},
+		{Word: "tea", Normalized: "tea", Offset: 6},
+	}, result)
+}
+
+func Test_SplitFuncTokenizer(t *testing.T) {
+	t.Run("must use the default splitter if nil", func(t *testing.T) {
+		result := tokenize(SplitFuncTokenizer(nil), "Green. (TEA)!")
+		require.Equal(t, []Token{
+			{Word: "Green", Normalized: "green", Offset: 0},
+			{Word: "TEA", Normalized: "tea", Offset: 8},
+		}, result)
+	})
+
+	t.Run("must find offsets of changed tokens", func(t *testing.T) {
+		upper := func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, token, err := bufio.ScanWords(data, atEOF)
+			if token != nil {
+				token = bytes.ToUpper(token)
+			}
+			return advance, token, err
+		}
+		result := tokenize(SplitFuncTokenizer(upper), "green  TEA")
+		require.Equal(t, []Token{
+			{Word: "GREEN", Normalized: "GREEN", Offset: 0},
+			{Word: "TEA", Normalized: "TEA", Offset: 7},
+		}, result)
+	})
+
+	t.Run("must stop when asked", func(t *testing.T) {
+		var result []string
+		err := SplitFuncTokenizer(bufio.ScanWords).Tokenize(strings.NewReader("a b c"), func(t Token) bool {
+			result = append(result, t.Word)
+			return len(result) < 2
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, result)
+	})
+}
+
+func Test_Spellchecker_AddFrom_DefaultTokenizer(t *testing.T) {
+	s, err := New(DefaultAlphabet)
+	require.NoError(t, err)
+	require.NoError(t, s.AddFrom(strings.NewReader("It's a well-known fact: e-mail abc123 don't co-operate. Hello.World")))
+
+	var words []string
+	s.Words(func(word string, _ int) bool {
+		words = append(words, word)
+		return true
+	})
+	require.Equal(t, []string{"a", "abc", "co-operate", "don", "e-mail", "fact", "hello", "it", "well-known"}, words)
+}
+
+func Test_Spellchecker_WithTokenizer(t *testing.T) {
+	s, err := New(DefaultAlphabet, WithTokenizer(WhitespaceTokenizer()))
+	require.NoError(t, err)
+
+	require.NoError(t, s.AddFrom(strings.NewReader("don't stop")))
+	require.True(t, s.IsCorrect("don't"))
+	tokens := s.Tokens("Don't stop")
+	require.Len(t, tokens, 2)
+	require.Equal(t, "don't", tokens[0].Normalized)
+	require.Empty(t, s.CheckText("Don't stop"))
+}
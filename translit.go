@@ -0,0 +1,272 @@
+package spellchecker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/agnivade/levenshtein"
+)
+
+// Transliteration is a scheme converting words of a non-latin script to latin symbols
+type Transliteration struct {
+	// Table maps lowercase symbols of the script to latin symbols. Other symbols are left unchanged
+	Table map[rune]string
+	// Weight multiplies scores of transliterated candidates, 1 if 0
+	Weight float64
+}
+
+// TranslitGOST is the Russian transliteration used in passports (GOST R 52535.1-2006, ICAO Doc 9303): "москва" => "moskva"
+var TranslitGOST = Transliteration{
+	Table: map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+		'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+		'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ъ': "ie", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+	},
+}
+
+// TranslitISO9 is the ISO 9:1995 (GOST 7.79-2000 system A) transliteration of Cyrillic: "жёлтый" => "žëltyj"
+var TranslitISO9 = Transliteration{
+	Table: map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë", 'ж': "ž", 'з': "z",
+		'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+		'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "c", 'ч': "č", 'ш': "š", 'щ': "ŝ",
+		'ъ': "ʺ", 'ы': "y", 'ь': "ʹ", 'э': "è", 'ю': "û", 'я': "â",
+		'і': "ì", 'ї': "ï", 'є': "ê", 'ґ': "g̀", 'ў': "ǔ",
+	},
+}
+
+// TranslitGreek is the ELOT 743 transliteration of Greek: "αθήνα" => "athina"
+var TranslitGreek = Transliteration{
+	Table: map[rune]string{
+		'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+		'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+		'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+		'ά': "a", 'έ': "e", 'ή': "i", 'ί': "i", 'ό': "o", 'ύ': "y", 'ώ': "o",
+		'ϊ': "i", 'ϋ': "y", 'ΐ': "i", 'ΰ': "y",
+	},
+}
+
+// translitIndex is a dictionary of transliterated words pointing to the words of the main dictionary
+type translitIndex struct {
+	table  map[rune]string
+	weight float64
+	// lossy symbols which can't be restored from their transliteration:
+	// the ones dropped or sharing the transliteration with other symbols ("е", "ё" => "e")
+	lossy map[rune]struct{}
+
+	dict *dictionary
+	// sources IDs of the main dictionary words by IDs of transliterated ones
+	sources map[uint32][]uint32
+}
+
+// WithTransliteration index transliterated dictionary words, so Latin-typed queries find words of other scripts:
+// "moskva" => "москва". Schemes are saved along with the dictionary, the index is rebuilt by Load()
+func WithTransliteration(schemes ...Transliteration) OptionFunc {
+	return func(s *Spellchecker) error {
+		return s.indexTransliterations(schemes)
+	}
+}
+
+// indexTransliterations builds transliteration indexes of the dictionary words
+func (s *Spellchecker) indexTransliterations(schemes []Transliteration) error {
+	translits := make([]*translitIndex, 0, len(schemes))
+	for _, scheme := range schemes {
+		ti, err := newTranslitIndex(scheme, s.dict)
+		if err != nil {
+			return err
+		}
+		for id, word := range s.dict.words {
+			ti.add(id, word)
+		}
+		translits = append(translits, ti)
+	}
+	s.translits = translits
+
+	return nil
+}
+
+// transliterations returns schemes of the transliteration indexes
+func (s *Spellchecker) transliterations() []Transliteration {
+	if len(s.translits) == 0 {
+		return nil
+	}
+
+	result := make([]Transliteration, len(s.translits))
+	for i, ti := range s.translits {
+		result[i] = Transliteration{Table: ti.table, Weight: ti.weight}
+	}
+
+	return result
+}
+
+func newTranslitIndex(scheme Transliteration, main *dictionary) (*translitIndex, error) {
+	// the alphabet consists of transliterated symbols and main alphabet symbols left unchanged
+	symbols := make(map[rune]struct{})
+	for _, latin := range scheme.Table {
+		for _, r := range latin {
+			symbols[r] = struct{}{}
+		}
+	}
+	for r := range main.alphabet {
+		if _, ok := scheme.Table[r]; !ok {
+			symbols[r] = struct{}{}
+		}
+	}
+	runes := make([]rune, 0, len(symbols))
+	for r := range symbols {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	dict, err := newDictionary(string(runes), main.scoreFunc, main.maxErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := scheme.Weight
+	if weight == 0 {
+		weight = 1
+	}
+
+	lossy := make(map[rune]struct{})
+	byLatin := make(map[string][]rune, len(scheme.Table))
+	for r, latin := range scheme.Table {
+		byLatin[latin] = append(byLatin[latin], r)
+	}
+	for latin, symbols := range byLatin {
+		if latin == "" || len(symbols) > 1 {
+			for _, r := range symbols {
+				lossy[r] = struct{}{}
+			}
+		}
+	}
+
+	return &translitIndex{
+		table:   scheme.Table,
+		weight:  weight,
+		lossy:   lossy,
+		dict:    dict,
+		sources: make(map[uint32][]uint32),
+	}, nil
+}
+
+// transliterate converts the word, returns false if the word has no symbols of the scheme
+func (ti *translitIndex) transliterate(word string) (string, bool) {
+	var sb strings.Builder
+	sb.Grow(len(word))
+	changed := false
+	for _, r := range word {
+		if latin, ok := ti.table[r]; ok {
+			sb.WriteString(latin)
+			changed = true
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String(), changed && sb.Len() > 0
+}
+
+// add indexes the main dictionary word
+func (ti *translitIndex) add(id uint32, word string) {
+	latin, ok := ti.transliterate(word)
+	if !ok {
+		return
+	}
+
+	tid := ti.dict.id(latin)
+	if tid == 0 {
+		tid, _ = ti.dict.add(latin)
+	}
+	ti.sources[tid] = append(ti.sources[tid], id)
+}
+
+// remove drops the main dictionary word from the index
+func (ti *translitIndex) remove(id uint32, word string) {
+	latin, ok := ti.transliterate(word)
+	if !ok {
+		return
+	}
+
+	tid := ti.dict.id(latin)
+	ids := ti.sources[tid]
+	for i := range ids {
+		if ids[i] == id {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	if len(ids) == 0 {
+		ti.dict.remove(tid)
+		delete(ti.sources, tid)
+		return
+	}
+	ti.sources[tid] = ids
+}
+
+// find searches transliterated words and scores the main dictionary words by their counters.
+// Scores are multiplied by the scheme weight and by the share of the word symbols restored unambiguously
+// from the transliteration, so a word is ranked lower by a scheme which loses more of its symbols
+func (ti *translitIndex) find(main *dictionary, word string, n int) []match {
+	var result []match
+	wordRunes := []rune(word)
+	for _, m := range ti.dict.find(word, n) {
+		candidate := []rune(m.Value)
+		distance := levenshtein.ComputeDistance(word, m.Value)
+		for _, id := range ti.sources[ti.dict.id(m.Value)] {
+			result = append(result, match{
+				Value: main.words[id],
+				Score: main.scoreFunc(wordRunes, candidate, distance, main.counts[id]) * ti.weight * ti.confidence(main.words[id]),
+			})
+		}
+	}
+
+	return result
+}
+
+// confidence returns a value in range (0.5, 1] decreasing with the share of lossy symbols in the word
+func (ti *translitIndex) confidence(word string) float64 {
+	total, lossy := 0, 0
+	for _, r := range word {
+		total++
+		if _, ok := ti.lossy[r]; ok {
+			lossy++
+		}
+	}
+	if total == 0 {
+		return 1
+	}
+
+	return 1 - 0.5*float64(lossy)/float64(total)
+}
+
+// find searches the main dictionary and transliteration indexes, returns top n candidates
+func (s *Spellchecker) find(word string, n int) []match {
+	hits := s.dict.find(word, n)
+	if len(s.translits) == 0 {
+		return hits
+	}
+
+	for _, ti := range s.translits {
+		hits = append(hits, ti.find(s.dict, word, n)...)
+	}
+
+	// a word may be found both in the main dictionary and in transliteration indexes
+	best := make(map[string]float64, len(hits))
+	for _, h := range hits {
+		if score, ok := best[h.Value]; !ok || h.Score > score {
+			best[h.Value] = h.Score
+		}
+	}
+	result := make([]match, 0, len(best))
+	for value, score := range best {
+		result = append(result, match{Value: value, Score: score})
+	}
+	sortMatches(result)
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
@@ -0,0 +1,130 @@
+package spellchecker
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cyrillicAlphabet = "абвгдеёжзийклмнопрстуфхцчшщъыьэюя"
+
+func Test_WithTransliteration(t *testing.T) {
+	newTranslitSpellchecker := func(t *testing.T, schemes ...Transliteration) *Spellchecker {
+		s, err := New(DefaultAlphabet+cyrillicAlphabet, WithTransliteration(schemes...))
+		require.NoError(t, err)
+		s.Add("москва", "москва", "жёлтый", "щука", "moscow")
+		return s
+	}
+
+	t.Run("must find words of another script", func(t *testing.T) {
+		s := newTranslitSpellchecker(t, TranslitGOST)
+		for input, expected := range map[string]string{"moskva": "москва", "Moskva": "Москва", "moskwa": "москва", "shchuka": "щука"} {
+			result, err := s.Fix(input)
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+	})
+
+	t.Run("must keep words of the same script", func(t *testing.T) {
+		s := newTranslitSpellchecker(t, TranslitGOST)
+		require.True(t, s.IsCorrect("moscow"))
+		require.False(t, s.IsCorrect("moskva"))
+
+		result, err := s.Fix("moscw")
+		require.NoError(t, err)
+		require.Equal(t, "moscow", result)
+	})
+
+	t.Run("must use ISO 9 scheme", func(t *testing.T) {
+		s := newTranslitSpellchecker(t, TranslitISO9)
+		result, err := s.Fix("žëltyj")
+		require.NoError(t, err)
+		require.Equal(t, "жёлтый", result)
+	})
+
+	t.Run("must use custom tables and weights", func(t *testing.T) {
+		s := newTranslitSpellchecker(t, TranslitGOST, Transliteration{
+			Table:  map[rune]string{'щ': "sch", 'у': "u", 'к': "k", 'а': "a"},
+			Weight: 0.5,
+		})
+		result, err := s.Suggest("schuka", 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"щука"}, result)
+	})
+
+	t.Run("must update index on dictionary changes", func(t *testing.T) {
+		s := newTranslitSpellchecker(t, TranslitGOST)
+		s.Remove("москва")
+		result, err := s.Fix("moskva")
+		require.ErrorIs(t, err, ErrUnknownWord)
+		require.Equal(t, "moskva", result)
+
+		s.Add("киев")
+		result, err = s.Fix("kiev")
+		require.NoError(t, err)
+		require.Equal(t, "киев", result)
+	})
+
+	t.Run("must index loaded dictionary", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, newTranslitSpellchecker(t, TranslitGOST).Save(buf))
+		s, err := Load(buf)
+		require.NoError(t, err)
+
+		result, err := s.Fix("moskva")
+		require.NoError(t, err)
+		require.Equal(t, "москва", result)
+	})
+
+	t.Run("must rank transliterated hits against direct hits by weight", func(t *testing.T) {
+		for _, tc := range []struct {
+			weight   float64
+			expected []string
+		}{
+			{weight: 1, expected: []string{"москва", "moskva"}},
+			{weight: 0.3, expected: []string{"moskva", "москва"}},
+		} {
+			s, err := New(DefaultAlphabet+cyrillicAlphabet, WithTransliteration(Transliteration{Table: TranslitGOST.Table, Weight: tc.weight}))
+			require.NoError(t, err)
+			s.Add("москва", "москва", "москва", "москва", "москва", "moskva")
+
+			result, err := s.Suggest("moskwa", 2)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		}
+	})
+
+	t.Run("must rank words with lossy symbols lower", func(t *testing.T) {
+		s, err := New(DefaultAlphabet+cyrillicAlphabet, WithTransliteration(Transliteration{
+			Table: map[rune]string{'а': "", 'б': "b", 'в': "v"},
+		}))
+		require.NoError(t, err)
+		s.Add("бав", "бв")
+
+		result, err := s.Suggest("bv", 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"бв", "бав"}, result)
+	})
+}
+
+func Test_translitIndex_confidence(t *testing.T) {
+	s, err := New(DefaultAlphabet + cyrillicAlphabet)
+	require.NoError(t, err)
+	ti, err := newTranslitIndex(TranslitGOST, s.dict)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, ti.confidence("москва"))
+	// "е" and "ё" share the transliteration, "ь" is dropped
+	require.Equal(t, 0.75, ti.confidence("ёж"))
+	require.Equal(t, 0.875, ti.confidence("мать"))
+}
+
+func Test_translitIndex_transliterate(t *testing.T) {
+	ti := &translitIndex{table: TranslitGreek.Table}
+	result, ok := ti.transliterate("αθήνα")
+	require.True(t, ok)
+	require.Equal(t, "athina", result)
+
+	_, ok = ti.transliterate("athens")
+	require.False(t, ok)
+}